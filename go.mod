@@ -2,10 +2,14 @@ module github.com/zemld/config-manager
 
 go 1.25.1
 
-require github.com/redis/go-redis/v9 v9.17.2
+require (
+	github.com/alicebob/miniredis/v2 v2.36.1
+	github.com/pelletier/go-toml/v2 v2.4.3
+	github.com/redis/go-redis/v9 v9.17.2
+	gopkg.in/yaml.v3 v3.0.1
+)
 
 require (
-	github.com/alicebob/miniredis/v2 v2.36.1 // indirect
 	github.com/cespare/xxhash/v2 v2.3.0 // indirect
 	github.com/dgryski/go-rendezvous v0.0.0-20200823014737-9f7001d12a5f // indirect
 	github.com/yuin/gopher-lua v1.1.1 // indirect
@@ -0,0 +1,56 @@
+// Package bcm provides a config manager built from a JSON document held in
+// memory. It is decoded and flattened exactly like a document loaded from
+// Redis, which makes it a faithful test double and a home for embedded
+// configs.
+package bcm
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/zemld/config-manager/pkg/cm"
+	"github.com/zemld/config-manager/pkg/cm/internal/conv"
+	"github.com/zemld/config-manager/pkg/cm/internal/kv"
+)
+
+// BytesConfigManager serves a fixed JSON document. The loading methods do
+// nothing, since there is no source to reload from.
+type BytesConfigManager struct {
+	*kv.Store
+}
+
+// NewBytesConfigManager decodes the JSON document in raw.
+func NewBytesConfigManager(raw []byte) (cm.ConfigManager, error) {
+	rawConfigMap := make(map[string]any)
+	if err := conv.UnmarshalJSON(raw, &rawConfigMap); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
+	}
+
+	bcm := &BytesConfigManager{
+		Store: kv.NewStore(),
+	}
+	kv.ReplaceDocument(bcm.Store, rawConfigMap)
+
+	return bcm, nil
+}
+
+// NewReaderConfigManager reads r to the end and decodes it like
+// NewBytesConfigManager.
+func NewReaderConfigManager(r io.Reader) (cm.ConfigManager, error) {
+	raw, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+
+	return NewBytesConfigManager(raw)
+}
+
+func (bcm *BytesConfigManager) StartLoading(interval time.Duration) {}
+
+func (bcm *BytesConfigManager) StopLoading() {}
+
+func (bcm *BytesConfigManager) LoadConfig(ctx context.Context) error {
+	return nil
+}
@@ -0,0 +1,64 @@
+package bcm
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zemld/config-manager/pkg/cm"
+)
+
+func TestNewBytesConfigManager(t *testing.T) {
+	raw := []byte(`{"port": 8080, "timeout": "5s", "db": {"hosts": ["a", "b"]}, "ratio": 1e3}`)
+
+	bcm, err := NewBytesConfigManager(raw)
+	if err != nil {
+		t.Fatalf("NewBytesConfigManager failed: %v", err)
+	}
+
+	if port, err := bcm.GetInt("port"); err != nil || port != 8080 {
+		t.Errorf("expected 8080, got %d (err: %v)", port, err)
+	}
+	if timeout, err := bcm.GetDuration("timeout"); err != nil || timeout != 5*time.Second {
+		t.Errorf("expected 5s, got %v (err: %v)", timeout, err)
+	}
+	if hosts, err := bcm.GetStringSlice("db.hosts"); err != nil || !reflect.DeepEqual(hosts, []string{"a", "b"}) {
+		t.Errorf("expected [a b], got %v (err: %v)", hosts, err)
+	}
+	if host, err := bcm.GetString("db.hosts.1"); err != nil || host != "b" {
+		t.Errorf("expected b, got %q (err: %v)", host, err)
+	}
+	if ratio, err := bcm.GetInt("ratio"); err != nil || ratio != 1000 {
+		t.Errorf("expected 1000, got %d (err: %v)", ratio, err)
+	}
+	if _, err := bcm.GetString("missing"); !errors.Is(err, cm.ErrKeyNotFound) {
+		t.Errorf("expected ErrKeyNotFound, got %v", err)
+	}
+
+	if err := bcm.LoadConfig(context.Background()); err != nil {
+		t.Errorf("expected LoadConfig to be a no-op, got %v", err)
+	}
+	if !bcm.IsLoaded() {
+		t.Error("expected the manager to be loaded")
+	}
+}
+
+func TestNewBytesConfigManager_Invalid(t *testing.T) {
+	if _, err := NewBytesConfigManager([]byte(`{"port": }`)); err == nil {
+		t.Error("expected an error for malformed JSON")
+	}
+}
+
+func TestNewReaderConfigManager(t *testing.T) {
+	bcm, err := NewReaderConfigManager(strings.NewReader(`{"name": "service"}`))
+	if err != nil {
+		t.Fatalf("NewReaderConfigManager failed: %v", err)
+	}
+
+	if name, err := bcm.GetString("name"); err != nil || name != "service" {
+		t.Errorf("expected service, got %q (err: %v)", name, err)
+	}
+}
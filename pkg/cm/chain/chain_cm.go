@@ -0,0 +1,421 @@
+// Package chain layers several config managers on top of each other, for
+// example environment variables over a config file over Redis.
+package chain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"image/color"
+	"net"
+	"net/url"
+	"regexp"
+	"time"
+
+	"github.com/zemld/config-manager/pkg/cm"
+)
+
+// ChainConfigManager reads each key from the first manager that has it.
+// Managers earlier in the chain take precedence over later ones.
+type ChainConfigManager struct {
+	managers []cm.ConfigManager
+}
+
+// NewChainConfigManager creates a manager that consults managers in order.
+// Loading methods are forwarded to every manager in the chain.
+func NewChainConfigManager(managers ...cm.ConfigManager) cm.ConfigManager {
+	return &ChainConfigManager{
+		managers: managers,
+	}
+}
+
+func (ccm *ChainConfigManager) StartLoading(interval time.Duration) {
+	for _, manager := range ccm.managers {
+		manager.StartLoading(interval)
+	}
+}
+
+func (ccm *ChainConfigManager) StopLoading() {
+	for _, manager := range ccm.managers {
+		manager.StopLoading()
+	}
+}
+
+// LoadConfig loads every manager in the chain, even if some fail, and
+// returns the joined errors.
+func (ccm *ChainConfigManager) LoadConfig(ctx context.Context) error {
+	var errs []error
+	for _, manager := range ccm.managers {
+		if err := manager.LoadConfig(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// LastUpdated returns the most recent update time of any manager.
+func (ccm *ChainConfigManager) LastUpdated() time.Time {
+	var updatedAt time.Time
+	for _, manager := range ccm.managers {
+		if t := manager.LastUpdated(); t.After(updatedAt) {
+			updatedAt = t
+		}
+	}
+
+	return updatedAt
+}
+
+// IsLoaded reports whether every manager in the chain has loaded.
+func (ccm *ChainConfigManager) IsLoaded() bool {
+	for _, manager := range ccm.managers {
+		if !manager.IsLoaded() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// first returns the value of the first manager that has key. Only
+// cm.ErrKeyNotFound falls through; any other error, such as a malformed
+// value in an overriding layer, is returned as is.
+func first[T any](ccm *ChainConfigManager, key string, get func(cm.ConfigManager) (T, error)) (T, error) {
+	for _, manager := range ccm.managers {
+		value, err := get(manager)
+		if errors.Is(err, cm.ErrKeyNotFound) {
+			continue
+		}
+
+		return value, err
+	}
+
+	var zero T
+	return zero, fmt.Errorf("%w: %s", cm.ErrKeyNotFound, key)
+}
+
+func (ccm *ChainConfigManager) GetInt(key string) (int, error) {
+	return first(ccm, key, func(manager cm.ConfigManager) (int, error) {
+		return manager.GetInt(key)
+	})
+}
+
+func (ccm *ChainConfigManager) GetFloat(key string) (float64, error) {
+	return first(ccm, key, func(manager cm.ConfigManager) (float64, error) {
+		return manager.GetFloat(key)
+	})
+}
+
+func (ccm *ChainConfigManager) GetString(key string) (string, error) {
+	return first(ccm, key, func(manager cm.ConfigManager) (string, error) {
+		return manager.GetString(key)
+	})
+}
+
+// GetRaw reads key through GetRaw on managers implementing cm.RawGetter and
+// through GetString on the rest.
+func (ccm *ChainConfigManager) GetRaw(key string) (string, error) {
+	return first(ccm, key, func(manager cm.ConfigManager) (string, error) {
+		if rawGetter, ok := manager.(cm.RawGetter); ok {
+			return rawGetter.GetRaw(key)
+		}
+		return manager.GetString(key)
+	})
+}
+
+func (ccm *ChainConfigManager) GetBool(key string) (bool, error) {
+	return first(ccm, key, func(manager cm.ConfigManager) (bool, error) {
+		return manager.GetBool(key)
+	})
+}
+
+func (ccm *ChainConfigManager) GetDuration(key string) (time.Duration, error) {
+	return first(ccm, key, func(manager cm.ConfigManager) (time.Duration, error) {
+		return manager.GetDuration(key)
+	})
+}
+
+func (ccm *ChainConfigManager) GetStringSlice(key string) ([]string, error) {
+	return first(ccm, key, func(manager cm.ConfigManager) ([]string, error) {
+		return manager.GetStringSlice(key)
+	})
+}
+
+func (ccm *ChainConfigManager) GetBytes(key string) ([]byte, error) {
+	return first(ccm, key, func(manager cm.ConfigManager) ([]byte, error) {
+		return manager.GetBytes(key)
+	})
+}
+
+func (ccm *ChainConfigManager) GetIntSlice(key string) ([]int, error) {
+	return first(ccm, key, func(manager cm.ConfigManager) ([]int, error) {
+		return manager.GetIntSlice(key)
+	})
+}
+
+func (ccm *ChainConfigManager) GetFloatSlice(key string) ([]float64, error) {
+	return first(ccm, key, func(manager cm.ConfigManager) ([]float64, error) {
+		return manager.GetFloatSlice(key)
+	})
+}
+
+func (ccm *ChainConfigManager) GetStringMap(key string) (map[string]string, error) {
+	return first(ccm, key, func(manager cm.ConfigManager) (map[string]string, error) {
+		return manager.GetStringMap(key)
+	})
+}
+
+func (ccm *ChainConfigManager) GetURL(key string) (*url.URL, error) {
+	return first(ccm, key, func(manager cm.ConfigManager) (*url.URL, error) {
+		return manager.GetURL(key)
+	})
+}
+
+func (ccm *ChainConfigManager) GetEnum(key string, allowed []string) (string, error) {
+	return first(ccm, key, func(manager cm.ConfigManager) (string, error) {
+		return manager.GetEnum(key, allowed)
+	})
+}
+
+func (ccm *ChainConfigManager) GetIP(key string) (net.IP, error) {
+	return first(ccm, key, func(manager cm.ConfigManager) (net.IP, error) {
+		return manager.GetIP(key)
+	})
+}
+
+func (ccm *ChainConfigManager) GetIPNet(key string) (*net.IPNet, error) {
+	return first(ccm, key, func(manager cm.ConfigManager) (*net.IPNet, error) {
+		return manager.GetIPNet(key)
+	})
+}
+
+func (ccm *ChainConfigManager) GetInt64(key string) (int64, error) {
+	return first(ccm, key, func(manager cm.ConfigManager) (int64, error) {
+		return manager.GetInt64(key)
+	})
+}
+
+func (ccm *ChainConfigManager) GetUint64(key string) (uint64, error) {
+	return first(ccm, key, func(manager cm.ConfigManager) (uint64, error) {
+		return manager.GetUint64(key)
+	})
+}
+
+func (ccm *ChainConfigManager) GetBytesSize(key string) (int64, error) {
+	return first(ccm, key, func(manager cm.ConfigManager) (int64, error) {
+		return manager.GetBytesSize(key)
+	})
+}
+
+func (ccm *ChainConfigManager) GetPercent(key string) (float64, error) {
+	return first(ccm, key, func(manager cm.ConfigManager) (float64, error) {
+		return manager.GetPercent(key)
+	})
+}
+
+func (ccm *ChainConfigManager) GetStringMatching(key string, re *regexp.Regexp) (string, error) {
+	return first(ccm, key, func(manager cm.ConfigManager) (string, error) {
+		return manager.GetStringMatching(key, re)
+	})
+}
+
+func (ccm *ChainConfigManager) GetDurationMillis(key string) (time.Duration, error) {
+	return first(ccm, key, func(manager cm.ConfigManager) (time.Duration, error) {
+		return manager.GetDurationMillis(key)
+	})
+}
+
+func (ccm *ChainConfigManager) GetColorRGBA(key string) (color.RGBA, error) {
+	return first(ccm, key, func(manager cm.ConfigManager) (color.RGBA, error) {
+		return manager.GetColorRGBA(key)
+	})
+}
+
+func (ccm *ChainConfigManager) GetIntWithDefault(key string, defaultValue int) int {
+	value, err := ccm.GetInt(key)
+	if err != nil && !errors.Is(err, cm.ErrStale) {
+		return defaultValue
+	}
+
+	return value
+}
+
+func (ccm *ChainConfigManager) GetFloatWithDefault(key string, defaultValue float64) float64 {
+	value, err := ccm.GetFloat(key)
+	if err != nil && !errors.Is(err, cm.ErrStale) {
+		return defaultValue
+	}
+
+	return value
+}
+
+func (ccm *ChainConfigManager) GetStringWithDefault(key string, defaultValue string) string {
+	value, err := ccm.GetString(key)
+	if err != nil && !errors.Is(err, cm.ErrStale) {
+		return defaultValue
+	}
+
+	return value
+}
+
+func (ccm *ChainConfigManager) GetBoolWithDefault(key string, defaultValue bool) bool {
+	value, err := ccm.GetBool(key)
+	if err != nil && !errors.Is(err, cm.ErrStale) {
+		return defaultValue
+	}
+
+	return value
+}
+
+func (ccm *ChainConfigManager) GetDurationWithDefault(key string, defaultValue time.Duration) time.Duration {
+	value, err := ccm.GetDuration(key)
+	if err != nil && !errors.Is(err, cm.ErrStale) {
+		return defaultValue
+	}
+
+	return value
+}
+
+func (ccm *ChainConfigManager) GetStringSliceWithDefault(key string, defaultValue []string) []string {
+	value, err := ccm.GetStringSlice(key)
+	if err != nil && !errors.Is(err, cm.ErrStale) {
+		return defaultValue
+	}
+
+	return value
+}
+
+func (ccm *ChainConfigManager) GetBytesWithDefault(key string, defaultValue []byte) []byte {
+	value, err := ccm.GetBytes(key)
+	if err != nil && !errors.Is(err, cm.ErrStale) {
+		return defaultValue
+	}
+
+	return value
+}
+
+func (ccm *ChainConfigManager) GetIntSliceWithDefault(key string, defaultValue []int) []int {
+	value, err := ccm.GetIntSlice(key)
+	if err != nil && !errors.Is(err, cm.ErrStale) {
+		return defaultValue
+	}
+
+	return value
+}
+
+func (ccm *ChainConfigManager) GetFloatSliceWithDefault(key string, defaultValue []float64) []float64 {
+	value, err := ccm.GetFloatSlice(key)
+	if err != nil && !errors.Is(err, cm.ErrStale) {
+		return defaultValue
+	}
+
+	return value
+}
+
+func (ccm *ChainConfigManager) GetStringMapWithDefault(key string, defaultValue map[string]string) map[string]string {
+	value, err := ccm.GetStringMap(key)
+	if err != nil && !errors.Is(err, cm.ErrStale) {
+		return defaultValue
+	}
+
+	return value
+}
+
+func (ccm *ChainConfigManager) GetURLWithDefault(key string, defaultValue *url.URL) *url.URL {
+	value, err := ccm.GetURL(key)
+	if err != nil && !errors.Is(err, cm.ErrStale) {
+		return defaultValue
+	}
+
+	return value
+}
+
+func (ccm *ChainConfigManager) GetEnumWithDefault(key string, allowed []string, defaultValue string) string {
+	value, err := ccm.GetEnum(key, allowed)
+	if err != nil && !errors.Is(err, cm.ErrStale) {
+		return defaultValue
+	}
+
+	return value
+}
+
+func (ccm *ChainConfigManager) GetIPWithDefault(key string, defaultValue net.IP) net.IP {
+	value, err := ccm.GetIP(key)
+	if err != nil && !errors.Is(err, cm.ErrStale) {
+		return defaultValue
+	}
+
+	return value
+}
+
+func (ccm *ChainConfigManager) GetIPNetWithDefault(key string, defaultValue *net.IPNet) *net.IPNet {
+	value, err := ccm.GetIPNet(key)
+	if err != nil && !errors.Is(err, cm.ErrStale) {
+		return defaultValue
+	}
+
+	return value
+}
+
+func (ccm *ChainConfigManager) GetInt64WithDefault(key string, defaultValue int64) int64 {
+	value, err := ccm.GetInt64(key)
+	if err != nil && !errors.Is(err, cm.ErrStale) {
+		return defaultValue
+	}
+
+	return value
+}
+
+func (ccm *ChainConfigManager) GetUint64WithDefault(key string, defaultValue uint64) uint64 {
+	value, err := ccm.GetUint64(key)
+	if err != nil && !errors.Is(err, cm.ErrStale) {
+		return defaultValue
+	}
+
+	return value
+}
+
+func (ccm *ChainConfigManager) GetBytesSizeWithDefault(key string, defaultValue int64) int64 {
+	value, err := ccm.GetBytesSize(key)
+	if err != nil && !errors.Is(err, cm.ErrStale) {
+		return defaultValue
+	}
+
+	return value
+}
+
+func (ccm *ChainConfigManager) GetPercentWithDefault(key string, defaultValue float64) float64 {
+	value, err := ccm.GetPercent(key)
+	if err != nil && !errors.Is(err, cm.ErrStale) {
+		return defaultValue
+	}
+
+	return value
+}
+
+func (ccm *ChainConfigManager) GetStringMatchingWithDefault(key string, re *regexp.Regexp, defaultValue string) string {
+	value, err := ccm.GetStringMatching(key, re)
+	if err != nil && !errors.Is(err, cm.ErrStale) {
+		return defaultValue
+	}
+
+	return value
+}
+
+func (ccm *ChainConfigManager) GetDurationMillisWithDefault(key string, defaultValue time.Duration) time.Duration {
+	value, err := ccm.GetDurationMillis(key)
+	if err != nil && !errors.Is(err, cm.ErrStale) {
+		return defaultValue
+	}
+
+	return value
+}
+
+func (ccm *ChainConfigManager) GetColorRGBAWithDefault(key string, defaultValue color.RGBA) color.RGBA {
+	value, err := ccm.GetColorRGBA(key)
+	if err != nil && !errors.Is(err, cm.ErrStale) {
+		return defaultValue
+	}
+
+	return value
+}
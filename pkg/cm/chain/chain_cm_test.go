@@ -0,0 +1,74 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/zemld/config-manager/pkg/cm"
+	"github.com/zemld/config-manager/pkg/cm/ecm"
+	"github.com/zemld/config-manager/pkg/cm/mcm"
+)
+
+func TestOverridePrecedence(t *testing.T) {
+	t.Setenv("CHAINTEST_PORT", "9090")
+
+	file := mcm.NewMockConfigManager(map[string]any{
+		"port":    8080,
+		"timeout": 5 * time.Second,
+	})
+	redis := mcm.NewMockConfigManager(map[string]any{
+		"port":    7070,
+		"timeout": time.Second,
+		"name":    "service",
+	})
+
+	ccm := NewChainConfigManager(ecm.NewEnvConfigManager("CHAINTEST_"), file, redis)
+
+	if port, err := ccm.GetInt("port"); err != nil || port != 9090 {
+		t.Errorf("expected env to win with 9090, got %d (err: %v)", port, err)
+	}
+	if timeout, err := ccm.GetDuration("timeout"); err != nil || timeout != 5*time.Second {
+		t.Errorf("expected file to win with 5s, got %v (err: %v)", timeout, err)
+	}
+	if name, err := ccm.GetString("name"); err != nil || name != "service" {
+		t.Errorf("expected redis value service, got %q (err: %v)", name, err)
+	}
+
+	if _, err := ccm.GetString("missing"); !errors.Is(err, cm.ErrKeyNotFound) {
+		t.Errorf("expected ErrKeyNotFound, got %v", err)
+	}
+	if value := ccm.GetStringWithDefault("missing", "default"); value != "default" {
+		t.Errorf("expected default, got %q", value)
+	}
+}
+
+func TestMalformedOverrideDoesNotFallThrough(t *testing.T) {
+	override := mcm.NewMockConfigManager(map[string]any{"port": "not a number"})
+	base := mcm.NewMockConfigManager(map[string]any{"port": 8080})
+
+	ccm := NewChainConfigManager(override, base)
+
+	if _, err := ccm.GetInt("port"); err == nil || errors.Is(err, cm.ErrKeyNotFound) {
+		t.Errorf("expected the override's type error, got %v", err)
+	}
+}
+
+func TestLoadConfigFansOut(t *testing.T) {
+	t.Setenv("CHAINTEST_NAME", "before")
+	env := ecm.NewEnvConfigManager("CHAINTEST_")
+	ccm := NewChainConfigManager(env, mcm.NewMockConfigManager(nil))
+
+	t.Setenv("CHAINTEST_NAME", "after")
+	if err := ccm.LoadConfig(context.Background()); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	if name, _ := ccm.GetString("name"); name != "after" {
+		t.Errorf("expected LoadConfig to reach every manager, got %q", name)
+	}
+	if ccm.LastUpdated().Before(env.LastUpdated()) {
+		t.Error("expected LastUpdated to be the latest of the chain")
+	}
+}
@@ -1,10 +1,37 @@
+// Package cm defines the ConfigManager interface implemented by the config
+// managers in its subpackages, and the errors their getters return.
+//
+// Some managers also offer OrEnv getters to ease a migration from
+// environment variables: a key that is missing or cannot be parsed falls
+// back to the environment variable envVar, and an unset, empty or
+// unparsable variable falls back to defaultValue.
+//
+// Their MustGet getters, where offered, are meant for initialization code
+// that cannot run without a setting. They panic if the key is missing or its value cannot be
+// parsed, like regexp.MustCompile does for a bad pattern.
 package cm
 
 import (
 	"context"
+	"errors"
+	"image/color"
+	"net"
+	"net/url"
+	"regexp"
 	"time"
 )
 
+var (
+	// ErrKeyNotFound is returned by getters when the key is not in the config.
+	ErrKeyNotFound = errors.New("config key not found")
+	// ErrTypeMismatch is returned by getters when the stored value has a
+	// different type than requested.
+	ErrTypeMismatch = errors.New("config value has wrong type")
+	// ErrStale is returned together with the value by managers configured
+	// with a maximum age when the config has not been refreshed in time.
+	ErrStale = errors.New("config is stale")
+)
+
 type ConfigManager interface {
 	ConfigLoader
 	ConfigGetter
@@ -15,14 +42,54 @@ type ConfigLoader interface {
 	StartLoading(interval time.Duration)
 	StopLoading()
 	LoadConfig(ctx context.Context) error
+	LastUpdated() time.Time
+	// IsLoaded reports whether a config has been loaded successfully at
+	// least once, which tells an empty config apart from a missing one.
+	IsLoaded() bool
+}
+
+// HealthChecker is implemented by managers that can report whether they are
+// ready to serve config, for example to a readiness probe.
+type HealthChecker interface {
+	HealthCheck(ctx context.Context) error
 }
 
 type ConfigGetter interface {
+	// GetInt returns a platform-sized int, which is 32 bits wide on 32-bit
+	// builds; use GetInt64 for IDs and counts that may exceed 2^31.
 	GetInt(key string) (int, error)
 	GetFloat(key string) (float64, error)
 	GetString(key string) (string, error)
 	GetBool(key string) (bool, error)
 	GetDuration(key string) (time.Duration, error)
+	GetStringSlice(key string) ([]string, error)
+	GetBytes(key string) ([]byte, error)
+	GetIntSlice(key string) ([]int, error)
+	GetFloatSlice(key string) ([]float64, error)
+	GetStringMap(key string) (map[string]string, error)
+	GetURL(key string) (*url.URL, error)
+	GetEnum(key string, allowed []string) (string, error)
+	GetIP(key string) (net.IP, error)
+	GetIPNet(key string) (*net.IPNet, error)
+	GetInt64(key string) (int64, error)
+	GetUint64(key string) (uint64, error)
+	GetBytesSize(key string) (int64, error)
+	GetPercent(key string) (float64, error)
+	GetStringMatching(key string, re *regexp.Regexp) (string, error)
+	GetDurationMillis(key string) (time.Duration, error)
+	GetColorRGBA(key string) (color.RGBA, error)
+}
+
+// ContextConfigGetter is implemented by managers whose getters can honor
+// cancellation and deadlines, such as backends that fetch keys lazily.
+type ContextConfigGetter interface {
+	GetIntContext(ctx context.Context, key string) (int, error)
+	GetFloatContext(ctx context.Context, key string) (float64, error)
+	GetStringContext(ctx context.Context, key string) (string, error)
+	GetBoolContext(ctx context.Context, key string) (bool, error)
+	GetDurationContext(ctx context.Context, key string) (time.Duration, error)
+	GetStringSliceContext(ctx context.Context, key string) ([]string, error)
+	GetBytesContext(ctx context.Context, key string) ([]byte, error)
 }
 
 type ConfigGetterWithDefault interface {
@@ -31,4 +98,20 @@ type ConfigGetterWithDefault interface {
 	GetStringWithDefault(key string, defaultValue string) string
 	GetBoolWithDefault(key string, defaultValue bool) bool
 	GetDurationWithDefault(key string, defaultValue time.Duration) time.Duration
+	GetStringSliceWithDefault(key string, defaultValue []string) []string
+	GetBytesWithDefault(key string, defaultValue []byte) []byte
+	GetIntSliceWithDefault(key string, defaultValue []int) []int
+	GetFloatSliceWithDefault(key string, defaultValue []float64) []float64
+	GetStringMapWithDefault(key string, defaultValue map[string]string) map[string]string
+	GetURLWithDefault(key string, defaultValue *url.URL) *url.URL
+	GetEnumWithDefault(key string, allowed []string, defaultValue string) string
+	GetIPWithDefault(key string, defaultValue net.IP) net.IP
+	GetIPNetWithDefault(key string, defaultValue *net.IPNet) *net.IPNet
+	GetInt64WithDefault(key string, defaultValue int64) int64
+	GetUint64WithDefault(key string, defaultValue uint64) uint64
+	GetBytesSizeWithDefault(key string, defaultValue int64) int64
+	GetPercentWithDefault(key string, defaultValue float64) float64
+	GetStringMatchingWithDefault(key string, re *regexp.Regexp, defaultValue string) string
+	GetDurationMillisWithDefault(key string, defaultValue time.Duration) time.Duration
+	GetColorRGBAWithDefault(key string, defaultValue color.RGBA) color.RGBA
 }
@@ -0,0 +1,82 @@
+// Package cmtest helps tests of code that depends on cm.ConfigManager set up
+// an in-memory manager in one expression.
+package cmtest
+
+import (
+	"time"
+
+	"github.com/zemld/config-manager/pkg/cm/mcm"
+)
+
+// Builder collects values and simulated conditions for an in-memory
+// manager. The zero value is not usable; call NewBuilder.
+type Builder struct {
+	data        map[string]any
+	loadErr     error
+	lastUpdated time.Time
+}
+
+func NewBuilder() *Builder {
+	return &Builder{
+		data: make(map[string]any),
+	}
+}
+
+func (b *Builder) WithInt(key string, value int) *Builder {
+	return b.WithValue(key, value)
+}
+
+func (b *Builder) WithFloat(key string, value float64) *Builder {
+	return b.WithValue(key, value)
+}
+
+func (b *Builder) WithString(key string, value string) *Builder {
+	return b.WithValue(key, value)
+}
+
+func (b *Builder) WithBool(key string, value bool) *Builder {
+	return b.WithValue(key, value)
+}
+
+func (b *Builder) WithDuration(key string, value time.Duration) *Builder {
+	return b.WithValue(key, value)
+}
+
+func (b *Builder) WithStringSlice(key string, value []string) *Builder {
+	return b.WithValue(key, value)
+}
+
+// WithValue stores a value of any type the in-memory manager understands.
+func (b *Builder) WithValue(key string, value any) *Builder {
+	b.data[key] = value
+	return b
+}
+
+// WithLoadError makes LoadConfig on the built manager return err.
+func (b *Builder) WithLoadError(err error) *Builder {
+	b.loadErr = err
+	return b
+}
+
+// WithStaleness makes the built manager report a last update age ago.
+func (b *Builder) WithStaleness(age time.Duration) *Builder {
+	b.lastUpdated = time.Now().Add(-age)
+	return b
+}
+
+// Build returns a manager holding the collected values. The builder may be
+// reused; later changes do not affect managers already built.
+func (b *Builder) Build() *mcm.InMemoryConfigManager {
+	data := make(map[string]any, len(b.data))
+	for key, value := range b.data {
+		data[key] = value
+	}
+
+	manager := mcm.NewMockConfigManager(data)
+	manager.SetLoadError(b.loadErr)
+	if !b.lastUpdated.IsZero() {
+		manager.SetLastUpdated(b.lastUpdated)
+	}
+
+	return manager
+}
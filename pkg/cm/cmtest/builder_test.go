@@ -0,0 +1,47 @@
+package cmtest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestBuilder(t *testing.T) {
+	errBackend := errors.New("backend down")
+
+	builder := NewBuilder().
+		WithInt("port", 8080).
+		WithString("host", "localhost").
+		WithBool("debug", true).
+		WithDuration("timeout", 5*time.Second).
+		WithLoadError(errBackend).
+		WithStaleness(time.Hour)
+
+	manager := builder.Build()
+
+	if port, err := manager.GetInt("port"); err != nil || port != 8080 {
+		t.Errorf("expected 8080, got %d (err: %v)", port, err)
+	}
+	if host, err := manager.GetString("host"); err != nil || host != "localhost" {
+		t.Errorf("expected localhost, got %q (err: %v)", host, err)
+	}
+	if debug, err := manager.GetBool("debug"); err != nil || !debug {
+		t.Errorf("expected true, got %v (err: %v)", debug, err)
+	}
+	if timeout, err := manager.GetDuration("timeout"); err != nil || timeout != 5*time.Second {
+		t.Errorf("expected 5s, got %v (err: %v)", timeout, err)
+	}
+
+	if err := manager.LoadConfig(context.Background()); !errors.Is(err, errBackend) {
+		t.Errorf("expected the simulated load error, got %v", err)
+	}
+	if age := time.Since(manager.LastUpdated()); age < time.Hour {
+		t.Errorf("expected the manager to look an hour stale, got %v", age)
+	}
+
+	builder.WithInt("port", 9090)
+	if port, _ := manager.GetInt("port"); port != 8080 {
+		t.Errorf("expected built managers to be unaffected by later changes, got %d", port)
+	}
+}
@@ -0,0 +1,84 @@
+package ecm
+
+import (
+	"context"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/zemld/config-manager/pkg/cm"
+	"github.com/zemld/config-manager/pkg/cm/internal/kv"
+)
+
+// EnvConfigManager reads config from environment variables sharing a prefix.
+// With prefix "MYSVC_" the variable MYSVC_INT_KEY is available as "int_key".
+type EnvConfigManager struct {
+	*kv.Store
+
+	prefix string
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewEnvConfigManager(prefix string) cm.ConfigManager {
+	ecm := &EnvConfigManager{
+		Store:  kv.NewStore(),
+		prefix: prefix,
+	}
+
+	ecm.LoadConfig(context.Background())
+
+	ecm.ctx, ecm.cancel = context.WithCancel(context.Background())
+	return ecm
+}
+
+func (ecm *EnvConfigManager) StartLoading(interval time.Duration) {
+	ecm.wg.Add(1)
+
+	go func() {
+		defer ecm.wg.Done()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ecm.ctx.Done():
+				return
+			case <-ticker.C:
+				ecm.LoadConfig(ecm.ctx)
+			}
+		}
+	}()
+}
+
+func (ecm *EnvConfigManager) StopLoading() {
+	ecm.cancel()
+	ecm.wg.Wait()
+}
+
+// LoadConfig takes a fresh snapshot of the process environment.
+func (ecm *EnvConfigManager) LoadConfig(ctx context.Context) error {
+	config := make(map[string]string)
+
+	for _, entry := range os.Environ() {
+		name, value, ok := strings.Cut(entry, "=")
+		if !ok || !strings.HasPrefix(name, ecm.prefix) {
+			continue
+		}
+
+		key := strings.ToLower(strings.TrimPrefix(name, ecm.prefix))
+		if key == "" {
+			continue
+		}
+
+		config[key] = value
+	}
+
+	kv.Replace(ecm.Store, config)
+
+	return nil
+}
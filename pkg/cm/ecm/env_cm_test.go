@@ -0,0 +1,56 @@
+package ecm
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestNewEnvConfigManager(t *testing.T) {
+	t.Setenv("ECMTEST_INT_KEY", "42")
+	t.Setenv("ECMTEST_FLOAT_KEY", "3.14")
+	t.Setenv("ECMTEST_STRING_KEY", "test_value")
+	t.Setenv("ECMTEST_BOOL_KEY", "true")
+	t.Setenv("ECMTEST_DURATION_KEY", "5s")
+	t.Setenv("OTHER_INT_KEY", "7")
+
+	ecm := NewEnvConfigManager("ECMTEST_")
+
+	if value, err := ecm.GetInt("int_key"); err != nil || value != 42 {
+		t.Errorf("expected 42, got %d (%v)", value, err)
+	}
+	if value, err := ecm.GetFloat("float_key"); err != nil || value != 3.14 {
+		t.Errorf("expected 3.14, got %f (%v)", value, err)
+	}
+	if value, err := ecm.GetString("string_key"); err != nil || value != "test_value" {
+		t.Errorf("expected 'test_value', got '%s' (%v)", value, err)
+	}
+	if value, err := ecm.GetBool("bool_key"); err != nil || !value {
+		t.Errorf("expected true, got %v (%v)", value, err)
+	}
+	if value, err := ecm.GetDuration("duration_key"); err != nil || value != 5*time.Second {
+		t.Errorf("expected 5s, got %v (%v)", value, err)
+	}
+
+	if _, err := ecm.GetInt("other_int_key"); err == nil {
+		t.Error("expected variables without the prefix to be ignored")
+	}
+}
+
+func TestLoadConfig_Resnapshots(t *testing.T) {
+	t.Setenv("ECMTEST_STRING_KEY", "old")
+
+	ecm := NewEnvConfigManager("ECMTEST_")
+
+	t.Setenv("ECMTEST_STRING_KEY", "new")
+	if value, _ := ecm.GetString("string_key"); value != "old" {
+		t.Errorf("expected 'old' before reload, got '%s'", value)
+	}
+
+	if err := ecm.LoadConfig(context.Background()); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if value, _ := ecm.GetString("string_key"); value != "new" {
+		t.Errorf("expected 'new' after reload, got '%s'", value)
+	}
+}
@@ -0,0 +1,26 @@
+package cm_test
+
+import (
+	"fmt"
+
+	"github.com/zemld/config-manager/pkg/cm"
+	"github.com/zemld/config-manager/pkg/cm/mcm"
+)
+
+func ExampleGetJSON() {
+	manager := mcm.NewMockConfigManager(map[string]any{
+		"db": `{"Host": "localhost", "Port": 5432}`,
+	})
+
+	db, err := cm.GetJSON[struct {
+		Host string
+		Port int
+	}](manager, "db")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Println(db.Host, db.Port)
+	// Output: localhost 5432
+}
@@ -0,0 +1,142 @@
+package fcm
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/zemld/config-manager/pkg/cm"
+	"github.com/zemld/config-manager/pkg/cm/internal/conv"
+	"github.com/zemld/config-manager/pkg/cm/internal/kv"
+	"gopkg.in/yaml.v3"
+)
+
+type decodeFunc func(data []byte, v any) error
+
+// FileConfigManager reads config from a JSON, YAML or TOML file.
+// Values are stored as text, so getters behave exactly like the Redis manager.
+type FileConfigManager struct {
+	*kv.Store
+
+	path   string
+	decode decodeFunc
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu      sync.Mutex
+	modTime time.Time
+	size    int64
+}
+
+// NewFileConfigManager loads the file at path. The format is detected by the
+// extension: .json, .yaml/.yml or .toml.
+func NewFileConfigManager(path string) (cm.ConfigManager, error) {
+	decode, err := decoderFor(path)
+	if err != nil {
+		return nil, err
+	}
+
+	fcm := &FileConfigManager{
+		Store:  kv.NewStore(),
+		path:   path,
+		decode: decode,
+	}
+
+	if err := fcm.LoadConfig(context.Background()); err != nil {
+		return nil, err
+	}
+
+	fcm.ctx, fcm.cancel = context.WithCancel(context.Background())
+	return fcm, nil
+}
+
+func decoderFor(path string) (decodeFunc, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return conv.UnmarshalJSON, nil
+	case ".yaml", ".yml":
+		return yaml.Unmarshal, nil
+	case ".toml":
+		return toml.Unmarshal, nil
+	}
+
+	return nil, fmt.Errorf("unsupported config file extension: %s", path)
+}
+
+// StartLoading checks the file every interval and reloads it when its
+// modification time or size changes.
+func (fcm *FileConfigManager) StartLoading(interval time.Duration) {
+	fcm.wg.Add(1)
+
+	go func() {
+		defer fcm.wg.Done()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		fcm.fetchUpdates(ticker)
+	}()
+}
+
+func (fcm *FileConfigManager) fetchUpdates(ticker *time.Ticker) {
+	for {
+		select {
+		case <-fcm.ctx.Done():
+			return
+		case <-ticker.C:
+			if fcm.modified() {
+				fcm.LoadConfig(fcm.ctx)
+			}
+		}
+	}
+}
+
+func (fcm *FileConfigManager) modified() bool {
+	info, err := os.Stat(fcm.path)
+	if err != nil {
+		return false
+	}
+
+	fcm.mu.Lock()
+	defer fcm.mu.Unlock()
+
+	return !info.ModTime().Equal(fcm.modTime) || info.Size() != fcm.size
+}
+
+func (fcm *FileConfigManager) StopLoading() {
+	fcm.cancel()
+	fcm.wg.Wait()
+}
+
+func (fcm *FileConfigManager) LoadConfig(ctx context.Context) error {
+	info, err := os.Stat(fcm.path)
+	if err != nil {
+		return fmt.Errorf("failed to stat config file: %w", err)
+	}
+
+	data, err := os.ReadFile(fcm.path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	rawConfigMap := make(map[string]any)
+	if err := fcm.decode(data, &rawConfigMap); err != nil {
+		return fmt.Errorf("failed to unmarshal config: %w", err)
+	}
+
+	fcm.mu.Lock()
+	defer fcm.mu.Unlock()
+
+	kv.ReplaceDocument(fcm.Store, rawConfigMap)
+	fcm.modTime = info.ModTime()
+	fcm.size = info.Size()
+
+	return nil
+}
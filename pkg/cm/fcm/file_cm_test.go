@@ -0,0 +1,120 @@
+package fcm
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/zemld/config-manager/pkg/cm"
+)
+
+func writeConfigFile(t *testing.T, path, content string) {
+	t.Helper()
+
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+}
+
+func TestNewFileConfigManager_Formats(t *testing.T) {
+	files := map[string]string{
+		"config.json": `{"int_key": 42, "float_key": 3.14, "string_key": "test_value", "bool_key": true, "duration_key": "5s"}`,
+		"config.yaml": "int_key: 42\nfloat_key: 3.14\nstring_key: test_value\nbool_key: true\nduration_key: 5s\n",
+		"config.toml": "int_key = 42\nfloat_key = 3.14\nstring_key = \"test_value\"\nbool_key = true\nduration_key = \"5s\"\n",
+	}
+
+	for name, content := range files {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), name)
+			writeConfigFile(t, path, content)
+
+			fcm, err := NewFileConfigManager(path)
+			if err != nil {
+				t.Fatalf("NewFileConfigManager failed: %v", err)
+			}
+
+			if value, err := fcm.GetInt("int_key"); err != nil || value != 42 {
+				t.Errorf("expected 42, got %d (%v)", value, err)
+			}
+			if value, err := fcm.GetFloat("float_key"); err != nil || value != 3.14 {
+				t.Errorf("expected 3.14, got %f (%v)", value, err)
+			}
+			if value, err := fcm.GetString("string_key"); err != nil || value != "test_value" {
+				t.Errorf("expected 'test_value', got '%s' (%v)", value, err)
+			}
+			if value, err := fcm.GetBool("bool_key"); err != nil || !value {
+				t.Errorf("expected true, got %v (%v)", value, err)
+			}
+			if value, err := fcm.GetDuration("duration_key"); err != nil || value != 5*time.Second {
+				t.Errorf("expected 5s, got %v (%v)", value, err)
+			}
+			if _, err := fcm.GetInt("nonexistent_key"); err == nil {
+				t.Error("expected error for nonexistent key")
+			}
+		})
+	}
+}
+
+func TestGetString_RejectsNestedValues(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeConfigFile(t, path, "db:\n  host: x\nhosts:\n  - a\n")
+
+	fcm, err := NewFileConfigManager(path)
+	if err != nil {
+		t.Fatalf("NewFileConfigManager failed: %v", err)
+	}
+
+	for _, key := range []string{"db", "hosts"} {
+		if _, err := fcm.GetString(key); !errors.Is(err, cm.ErrTypeMismatch) {
+			t.Errorf("expected ErrTypeMismatch for %s, got %v", key, err)
+		}
+	}
+	if value, err := fcm.GetString("db.host"); err != nil || value != "x" {
+		t.Errorf("expected 'x', got '%s' (%v)", value, err)
+	}
+}
+
+func TestNewFileConfigManager_Errors(t *testing.T) {
+	dir := t.TempDir()
+
+	if _, err := NewFileConfigManager(filepath.Join(dir, "config.ini")); err == nil {
+		t.Error("expected error for unsupported extension")
+	}
+
+	if _, err := NewFileConfigManager(filepath.Join(dir, "missing.json")); err == nil {
+		t.Error("expected error for missing file")
+	}
+
+	path := filepath.Join(dir, "invalid.json")
+	writeConfigFile(t, path, "invalid json")
+	if _, err := NewFileConfigManager(path); err == nil {
+		t.Error("expected error for invalid JSON")
+	}
+}
+
+func TestStartLoading_ReloadsModifiedFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	writeConfigFile(t, path, `{"string_key": "old"}`)
+
+	fcm, err := NewFileConfigManager(path)
+	if err != nil {
+		t.Fatalf("NewFileConfigManager failed: %v", err)
+	}
+
+	fcm.StartLoading(10 * time.Millisecond)
+	defer fcm.StopLoading()
+
+	writeConfigFile(t, path, `{"string_key": "updated"}`)
+
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if value, _ := fcm.GetString("string_key"); value == "updated" {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	t.Error("config was not reloaded after the file changed")
+}
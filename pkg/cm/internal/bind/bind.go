@@ -0,0 +1,105 @@
+// Package bind fills struct fields from a config getter.
+package bind
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/zemld/config-manager/pkg/cm"
+)
+
+const tagName = "cm"
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// Struct sets the exported fields of the struct v points to. The key for a
+// field is its cm tag, or the field name when there is no tag; fields tagged
+// "-" are skipped. Keys for which has reports false leave the field
+// untouched. Every field that fails is reported in the returned
+// *cm.UnmarshalError.
+func Struct(getter cm.ConfigGetter, has func(key string) bool, v any) error {
+	target := reflect.ValueOf(v)
+	if target.Kind() != reflect.Pointer || target.IsNil() || target.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("unmarshal target must be a non-nil pointer to a struct, got %T", v)
+	}
+
+	target = target.Elem()
+	targetType := target.Type()
+
+	var fieldErrs []*cm.FieldError
+	for i := 0; i < targetType.NumField(); i++ {
+		field := targetType.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		key := field.Name
+		if tag, ok := field.Tag.Lookup(tagName); ok {
+			if tag == "-" {
+				continue
+			}
+			key = tag
+		}
+
+		if !has(key) {
+			continue
+		}
+
+		if err := setField(getter, key, target.Field(i)); err != nil {
+			fieldErrs = append(fieldErrs, &cm.FieldError{Field: field.Name, Key: key, Err: err})
+		}
+	}
+
+	if len(fieldErrs) > 0 {
+		return &cm.UnmarshalError{Fields: fieldErrs}
+	}
+
+	return nil
+}
+
+func setField(getter cm.ConfigGetter, key string, field reflect.Value) error {
+	if field.Type() == durationType {
+		value, err := getter.GetDuration(key)
+		if err != nil && !errors.Is(err, cm.ErrStale) {
+			return err
+		}
+		field.SetInt(int64(value))
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		value, err := getter.GetInt(key)
+		if err != nil && !errors.Is(err, cm.ErrStale) {
+			return err
+		}
+		if field.OverflowInt(int64(value)) {
+			return fmt.Errorf("value %d overflows %s", value, field.Type())
+		}
+		field.SetInt(int64(value))
+	case reflect.Float32, reflect.Float64:
+		value, err := getter.GetFloat(key)
+		if err != nil && !errors.Is(err, cm.ErrStale) {
+			return err
+		}
+		field.SetFloat(value)
+	case reflect.String:
+		value, err := getter.GetString(key)
+		if err != nil && !errors.Is(err, cm.ErrStale) {
+			return err
+		}
+		field.SetString(value)
+	case reflect.Bool:
+		value, err := getter.GetBool(key)
+		if err != nil && !errors.Is(err, cm.ErrStale) {
+			return err
+		}
+		field.SetBool(value)
+	default:
+		return fmt.Errorf("unsupported field type %s", field.Type())
+	}
+
+	return nil
+}
@@ -0,0 +1,476 @@
+// Package conv converts raw config values to and from their text form so that
+// every string-backed manager parses values the same way.
+package conv
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image/color"
+	"io"
+	"math"
+	"net"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// UnmarshalJSON decodes data with UseNumber so numbers keep their exact
+// textual representation instead of being rounded through float64.
+func UnmarshalJSON(data []byte, v any) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	if err := decoder.Decode(v); err != nil {
+		return err
+	}
+
+	if _, err := decoder.Token(); err != io.EOF {
+		return errors.New("unexpected data after top-level value")
+	}
+
+	return nil
+}
+
+// Flatten turns a decoded document into text values. Nested objects and
+// arrays are kept as JSON under their own key and are also expanded into
+// dotted keys, so {"db": {"hosts": ["a"]}} yields "db", "db.hosts" and
+// "db.hosts.0". Null values are left out, so a key set to null reads as
+// missing rather than as the text "<nil>".
+func Flatten(document map[string]any) map[string]string {
+	result, _ := FlattenKinds(document)
+	return result
+}
+
+// FlattenKinds works like Flatten and also reports which keys held an
+// object or an array in the document.
+func FlattenKinds(document map[string]any) (map[string]string, map[string]bool) {
+	result, natives := FlattenNative(document)
+	composites := make(map[string]bool)
+	for key, value := range natives {
+		if IsComposite(value) {
+			composites[key] = true
+		}
+	}
+
+	return result, composites
+}
+
+// FlattenNative works like Flatten and also returns the decoded value of
+// every key, so callers can tell a JSON number or boolean from a string.
+func FlattenNative(document map[string]any) (map[string]string, map[string]any) {
+	result := make(map[string]string, len(document))
+	natives := make(map[string]any, len(document))
+	for key, value := range document {
+		flatten(result, natives, key, value)
+	}
+
+	return result, natives
+}
+
+// IsComposite reports whether a decoded value is an object or an array.
+func IsComposite(value any) bool {
+	switch value.(type) {
+	case map[string]any, []any:
+		return true
+	}
+	return false
+}
+
+func flatten(result map[string]string, natives map[string]any, key string, value any) {
+	if value == nil {
+		return
+	}
+	result[key] = Stringify(value)
+	natives[key] = value
+
+	switch nested := value.(type) {
+	case map[string]any:
+		for nestedKey, nestedValue := range nested {
+			flatten(result, natives, key+"."+nestedKey, nestedValue)
+		}
+	case []any:
+		for i, item := range nested {
+			flatten(result, natives, key+"."+strconv.Itoa(i), item)
+		}
+	}
+}
+
+// Stringify keeps lists and objects as JSON so they can be decoded again by
+// getters such as GetStringSlice.
+func Stringify(value any) string {
+	switch value.(type) {
+	case []any, map[string]any:
+		raw, err := json.Marshal(value)
+		if err == nil {
+			return string(raw)
+		}
+	}
+
+	return fmt.Sprintf("%v", value)
+}
+
+// Int also accepts float-formatted integers such as "1e+06" or "42.0", which
+// JSON numbers turn into after a round trip, as long as they have no
+// fractional part.
+func Int(value string) (int, error) {
+	intValue, err := strconv.Atoi(value)
+	if err == nil {
+		return intValue, nil
+	}
+
+	floatValue, floatErr := strconv.ParseFloat(value, 64)
+	if floatErr != nil {
+		return 0, err
+	}
+
+	if floatValue != math.Trunc(floatValue) {
+		return 0, fmt.Errorf("value %s is not an integer", value)
+	}
+
+	if floatValue < float64(math.MinInt) || floatValue >= -float64(math.MinInt) {
+		return 0, fmt.Errorf("value %s is out of int range", value)
+	}
+
+	return int(floatValue), nil
+}
+
+// Int64 parses a base 10 integer that fits in 64 bits on every platform.
+func Int64(key, value string) (int64, error) {
+	intValue, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("key %s is not a valid int64: %w", key, err)
+	}
+
+	return intValue, nil
+}
+
+// Uint64 parses a base 10 unsigned integer that fits in 64 bits.
+func Uint64(key, value string) (uint64, error) {
+	uintValue, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("key %s is not a valid uint64: %w", key, err)
+	}
+
+	return uintValue, nil
+}
+
+func Float(value string) (float64, error) {
+	return strconv.ParseFloat(value, 64)
+}
+
+// DecimalCommaFloat also accepts a comma as the decimal separator, as
+// written by locale-aware tools, so "3,14" parses as 3.14. A value with both
+// a comma and a dot is rejected, since it is ambiguous which one separates
+// thousands.
+func DecimalCommaFloat(value string) (float64, error) {
+	if strings.Count(value, ",") == 1 && !strings.Contains(value, ".") {
+		value = strings.Replace(value, ",", ".", 1)
+	}
+
+	return strconv.ParseFloat(value, 64)
+}
+
+// Bool accepts the spellings people use for switches in config files, case
+// insensitively: true/false, t/f, yes/no, y/n, on/off, 1/0 and
+// enabled/disabled. Anything else is an error.
+func Bool(value string) (bool, error) {
+	switch strings.ToLower(value) {
+	case "true", "t", "yes", "y", "on", "1", "enabled":
+		return true, nil
+	case "false", "f", "no", "n", "off", "0", "disabled":
+		return false, nil
+	}
+
+	return false, &strconv.NumError{Func: "ParseBool", Num: value, Err: strconv.ErrSyntax}
+}
+
+var (
+	dayWeekComponent = regexp.MustCompile(`(\d*\.?\d+)([dw])`)
+	isoDuration      = regexp.MustCompile(`^P(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d*\.?\d+)H)?(?:(\d*\.?\d+)M)?(?:(\d*\.?\d+)S)?)?$`)
+)
+
+// Duration accepts everything time.ParseDuration does plus days ("d") and
+// weeks ("w"), as in "1w2d", and ISO 8601 durations such as "PT90M" or
+// "P1DT12H". Days are always 24 hours; ISO years and months are rejected
+// because their length varies.
+func Duration(value string) (time.Duration, error) {
+	if strings.HasPrefix(value, "P") {
+		return isoDurationValue(value)
+	}
+
+	expanded := dayWeekComponent.ReplaceAllStringFunc(value, func(component string) string {
+		match := dayWeekComponent.FindStringSubmatch(component)
+		hours, _ := strconv.ParseFloat(match[1], 64)
+		if match[2] == "w" {
+			hours *= 7
+		}
+
+		return strconv.FormatFloat(hours*24, 'f', -1, 64) + "h"
+	})
+
+	return time.ParseDuration(expanded)
+}
+
+// DurationMillis reads a bare integer as a number of milliseconds and
+// parses anything else like Duration.
+func DurationMillis(value string) (time.Duration, error) {
+	if millis, err := Int(value); err == nil {
+		return time.Duration(millis) * time.Millisecond, nil
+	}
+
+	return Duration(value)
+}
+
+func isoDurationValue(value string) (time.Duration, error) {
+	match := isoDuration.FindStringSubmatch(value)
+	if match == nil || value == "P" || strings.HasSuffix(value, "T") {
+		return 0, fmt.Errorf("invalid ISO 8601 duration %q", value)
+	}
+
+	var goDuration strings.Builder
+	for i, unit := range []string{"w", "d", "h", "m", "s"} {
+		if match[i+1] != "" {
+			goDuration.WriteString(match[i+1] + unit)
+		}
+	}
+
+	return Duration(goDuration.String())
+}
+
+// StringSlice parses the value as a JSON array and falls back to splitting
+// it by commas. An empty value yields an empty slice.
+func StringSlice(value string) []string {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return []string{}
+	}
+
+	var items []any
+	if err := UnmarshalJSON([]byte(value), &items); err == nil {
+		result := make([]string, 0, len(items))
+		for _, item := range items {
+			if item == nil {
+				result = append(result, "")
+				continue
+			}
+			result = append(result, fmt.Sprintf("%v", item))
+		}
+		return result
+	}
+
+	parts := strings.Split(value, ",")
+	result := make([]string, 0, len(parts))
+	for _, part := range parts {
+		result = append(result, strings.TrimSpace(part))
+	}
+
+	return result
+}
+
+// IntSlice parses the value like StringSlice and converts every element with
+// Int. The error names the index of the first bad element.
+func IntSlice(key, value string) ([]int, error) {
+	items := StringSlice(value)
+
+	result := make([]int, 0, len(items))
+	for i, item := range items {
+		intValue, err := Int(item)
+		if err != nil {
+			return nil, fmt.Errorf("key %s: element %d is not an int: %w", key, i, err)
+		}
+		result = append(result, intValue)
+	}
+
+	return result, nil
+}
+
+// FloatSlice parses the value like StringSlice and converts every element
+// with Float. The error names the index of the first bad element.
+func FloatSlice(key, value string) ([]float64, error) {
+	items := StringSlice(value)
+
+	result := make([]float64, 0, len(items))
+	for i, item := range items {
+		floatValue, err := Float(item)
+		if err != nil {
+			return nil, fmt.Errorf("key %s: element %d is not a float: %w", key, i, err)
+		}
+		result = append(result, floatValue)
+	}
+
+	return result, nil
+}
+
+// StringMap parses the value as a JSON object. Nested values are returned in
+// their text form, with objects and arrays kept as JSON.
+func StringMap(key, value string) (map[string]string, error) {
+	var object map[string]any
+	if err := UnmarshalJSON([]byte(value), &object); err != nil || object == nil {
+		return nil, fmt.Errorf("key %s is not a JSON object", key)
+	}
+
+	result := make(map[string]string, len(object))
+	for objectKey, objectValue := range object {
+		result[objectKey] = Stringify(objectValue)
+	}
+
+	return result, nil
+}
+
+// URL parses the value and rejects URLs without a scheme, such as relative
+// references.
+func URL(key, value string) (*url.URL, error) {
+	parsed, err := url.Parse(value)
+	if err != nil {
+		return nil, fmt.Errorf("key %s is not a valid url: %w", key, err)
+	}
+
+	if parsed.Scheme == "" {
+		return nil, fmt.Errorf("key %s: url %q has no scheme", key, value)
+	}
+
+	return parsed, nil
+}
+
+// IP parses an IPv4 or IPv6 address.
+func IP(key, value string) (net.IP, error) {
+	ip := net.ParseIP(value)
+	if ip == nil {
+		return nil, fmt.Errorf("key %s: %q is not a valid ip address", key, value)
+	}
+
+	return ip, nil
+}
+
+// IPNet parses a CIDR range such as 10.0.0.0/8.
+func IPNet(key, value string) (*net.IPNet, error) {
+	_, ipNet, err := net.ParseCIDR(value)
+	if err != nil {
+		return nil, fmt.Errorf("key %s is not a valid cidr: %w", key, err)
+	}
+
+	return ipNet, nil
+}
+
+var byteUnits = map[string]float64{
+	"":    1,
+	"b":   1,
+	"kb":  1e3,
+	"mb":  1e6,
+	"gb":  1e9,
+	"tb":  1e12,
+	"kib": 1 << 10,
+	"mib": 1 << 20,
+	"gib": 1 << 30,
+	"tib": 1 << 40,
+}
+
+// BytesSize parses a size such as "512MB", "1.5GiB" or "10kb" into a byte
+// count. Decimal (KB, MB, GB, TB) and binary (KiB, MiB, GiB, TiB) units are
+// accepted case-insensitively; a bare number is a count of bytes.
+func BytesSize(key, value string) (int64, error) {
+	trimmed := strings.TrimSpace(value)
+	number := strings.TrimRightFunc(trimmed, unicode.IsLetter)
+	unit := strings.ToLower(trimmed[len(number):])
+
+	multiplier, ok := byteUnits[unit]
+	if !ok {
+		return 0, fmt.Errorf("key %s: unknown size unit in %q", key, value)
+	}
+
+	size, err := strconv.ParseFloat(strings.TrimSpace(number), 64)
+	if err != nil || size < 0 {
+		return 0, fmt.Errorf("key %s: %q is not a valid size", key, value)
+	}
+
+	bytes := size * multiplier
+	if bytes != math.Trunc(bytes) || bytes >= math.MaxInt64 {
+		return 0, fmt.Errorf("key %s: %q is not a whole number of bytes in int64 range", key, value)
+	}
+
+	return int64(bytes), nil
+}
+
+// Percent returns a fraction in [0, 1]. It accepts a percentage such as
+// "25%" or a bare fraction such as "0.25".
+func Percent(key, value string) (float64, error) {
+	trimmed := strings.TrimSpace(value)
+	number, isPercent := strings.CutSuffix(trimmed, "%")
+
+	fraction, err := strconv.ParseFloat(strings.TrimSpace(number), 64)
+	if err != nil {
+		return 0, fmt.Errorf("key %s: %q is not a valid percentage", key, value)
+	}
+	if isPercent {
+		fraction /= 100
+	}
+
+	if !(fraction >= 0 && fraction <= 1) {
+		return 0, fmt.Errorf("key %s: %q is outside 0%%-100%%", key, value)
+	}
+
+	return fraction, nil
+}
+
+// Enum returns the value if it is one of allowed.
+func Enum(key, value string, allowed []string) (string, error) {
+	if slices.Contains(allowed, value) {
+		return value, nil
+	}
+
+	return "", fmt.Errorf("key %s: value %q is not one of [%s]", key, value, strings.Join(allowed, ", "))
+}
+
+// Matching returns value if re matches it.
+func Matching(key, value string, re *regexp.Regexp) (string, error) {
+	if re.MatchString(value) {
+		return value, nil
+	}
+
+	return "", fmt.Errorf("key %s: value %q does not match pattern %s", key, value, re)
+}
+
+// ColorRGBA parses a hex color in #RGB, #RRGGBB or #RRGGBBAA form; the
+// leading # is optional. Colors without an alpha channel are opaque. The hex
+// digits are straight alpha, so the channels are premultiplied to give a
+// valid color.RGBA: "#ff000080" yields {128, 0, 0, 128}.
+func ColorRGBA(key, value string) (color.RGBA, error) {
+	digits := strings.TrimPrefix(strings.TrimSpace(value), "#")
+	if len(digits) == 3 {
+		digits = string([]byte{digits[0], digits[0], digits[1], digits[1], digits[2], digits[2]})
+	}
+	if len(digits) == 6 {
+		digits += "ff"
+	}
+
+	rgba, err := strconv.ParseUint(digits, 16, 32)
+	if len(digits) != 8 || err != nil {
+		return color.RGBA{}, fmt.Errorf("key %s: %q is not a hex color", key, value)
+	}
+
+	straight := color.NRGBA{R: uint8(rgba >> 24), G: uint8(rgba >> 16), B: uint8(rgba >> 8), A: uint8(rgba)}
+	return color.RGBAModel.Convert(straight).(color.RGBA), nil
+}
+
+// Bytes decodes a standard base64 value.
+func Bytes(key, value string) ([]byte, error) {
+	decoded, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("key %s is not valid base64: %w", key, err)
+	}
+
+	return decoded, nil
+}
+
+// MustMessage is the panic message of the MustGet getters when reading key
+// failed with err.
+func MustMessage(key string, err error) string {
+	return fmt.Sprintf("required config key %q: %v", key, err)
+}
@@ -0,0 +1,338 @@
+package conv
+
+import (
+	"image/color"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestInt(t *testing.T) {
+	valid := map[string]int{
+		"42":      42,
+		"-7":      -7,
+		"1000000": 1000000,
+		"1e6":     1000000,
+		"1e+06":   1000000,
+		"42.0":    42,
+	}
+
+	for value, expected := range valid {
+		got, err := Int(value)
+		if err != nil {
+			t.Errorf("Int(%q) failed: %v", value, err)
+			continue
+		}
+		if got != expected {
+			t.Errorf("Int(%q): expected %d, got %d", value, expected, got)
+		}
+	}
+
+	invalid := []string{"", "abc", "42.5", "1e-3", "1e300", "NaN"}
+	for _, value := range invalid {
+		if _, err := Int(value); err == nil {
+			t.Errorf("Int(%q): expected error", value)
+		}
+	}
+}
+
+func TestBool(t *testing.T) {
+	valid := map[string]bool{
+		"true":     true,
+		"TRUE":     true,
+		"t":        true,
+		"yes":      true,
+		"Yes":      true,
+		"y":        true,
+		"Y":        true,
+		"on":       true,
+		"ON":       true,
+		"1":        true,
+		"enabled":  true,
+		"Enabled":  true,
+		"false":    false,
+		"False":    false,
+		"f":        false,
+		"no":       false,
+		"NO":       false,
+		"n":        false,
+		"off":      false,
+		"Off":      false,
+		"0":        false,
+		"disabled": false,
+		"DISABLED": false,
+	}
+
+	for value, expected := range valid {
+		got, err := Bool(value)
+		if err != nil {
+			t.Errorf("Bool(%q) failed: %v", value, err)
+			continue
+		}
+		if got != expected {
+			t.Errorf("Bool(%q): expected %v, got %v", value, expected, got)
+		}
+	}
+
+	invalid := []string{"", "maybe", "2", "-1", "yess", "o", " true", "enable", "nope"}
+	for _, value := range invalid {
+		if _, err := Bool(value); err == nil {
+			t.Errorf("Bool(%q): expected error", value)
+		}
+	}
+}
+
+func TestFlatten(t *testing.T) {
+	document := map[string]any{
+		"db": map[string]any{
+			"host":  "x",
+			"hosts": []any{"a", "b"},
+		},
+		"list": []any{"first", map[string]any{"name": "second"}},
+		"flat": "value",
+	}
+
+	expected := map[string]string{
+		"db":          `{"host":"x","hosts":["a","b"]}`,
+		"db.host":     "x",
+		"db.hosts":    `["a","b"]`,
+		"db.hosts.0":  "a",
+		"db.hosts.1":  "b",
+		"list":        `["first",{"name":"second"}]`,
+		"list.0":      "first",
+		"list.1":      `{"name":"second"}`,
+		"list.1.name": "second",
+		"flat":        "value",
+	}
+
+	got, composites := FlattenKinds(document)
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+
+	expectedComposites := map[string]bool{"db": true, "db.hosts": true, "list": true, "list.1": true}
+	if !reflect.DeepEqual(composites, expectedComposites) {
+		t.Errorf("expected composites %v, got %v", expectedComposites, composites)
+	}
+}
+
+func TestBytesSize(t *testing.T) {
+	valid := map[string]int64{
+		"0":       0,
+		"1024":    1024,
+		"512B":    512,
+		"10kb":    10000,
+		"10KB":    10000,
+		"512MB":   512000000,
+		"2GB":     2000000000,
+		"1KiB":    1024,
+		"256MiB":  256 << 20,
+		"1GiB":    1 << 30,
+		"1.5GiB":  3 << 29,
+		"2 TiB":   2 << 40,
+		" 64mib ": 64 << 20,
+	}
+
+	for value, expected := range valid {
+		got, err := BytesSize("size", value)
+		if err != nil {
+			t.Errorf("BytesSize(%q) failed: %v", value, err)
+			continue
+		}
+		if got != expected {
+			t.Errorf("BytesSize(%q): expected %d, got %d", value, expected, got)
+		}
+	}
+
+	invalid := []string{"", "MB", "-1MB", "10XB", "1.5", "0.3B", "ten MB", "9999999TiB"}
+	for _, value := range invalid {
+		if _, err := BytesSize("size", value); err == nil {
+			t.Errorf("BytesSize(%q): expected error", value)
+		}
+	}
+}
+
+func TestPercent(t *testing.T) {
+	valid := map[string]float64{
+		"25%":   0.25,
+		"0%":    0,
+		"100%":  1,
+		"12.5%": 0.125,
+		" 50 %": 0.5,
+		"0.25":  0.25,
+		"0":     0,
+		"1":     1,
+	}
+
+	for value, expected := range valid {
+		got, err := Percent("rate", value)
+		if err != nil {
+			t.Errorf("Percent(%q) failed: %v", value, err)
+			continue
+		}
+		if got != expected {
+			t.Errorf("Percent(%q): expected %v, got %v", value, expected, got)
+		}
+	}
+
+	invalid := []string{"", "%", "abc", "101%", "-5%", "1.5", "-0.1", "NaN", "NaN%"}
+	for _, value := range invalid {
+		if _, err := Percent("rate", value); err == nil {
+			t.Errorf("Percent(%q): expected error", value)
+		}
+	}
+}
+
+func TestColorRGBA(t *testing.T) {
+	valid := map[string]color.RGBA{
+		"#1e90ff":   {R: 0x1e, G: 0x90, B: 0xff, A: 0xff},
+		"1E90FF":    {R: 0x1e, G: 0x90, B: 0xff, A: 0xff},
+		"#fff":      {R: 0xff, G: 0xff, B: 0xff, A: 0xff},
+		"a0c":       {R: 0xaa, G: 0x00, B: 0xcc, A: 0xff},
+		"#1e90ff80": {R: 0x0f, G: 0x48, B: 0x80, A: 0x80},
+		"#ff000080": {R: 0x80, A: 0x80},
+		"#ffffff00": {},
+		" #000000 ": {A: 0xff},
+	}
+
+	for value, expected := range valid {
+		got, err := ColorRGBA("theme", value)
+		if err != nil {
+			t.Errorf("ColorRGBA(%q) failed: %v", value, err)
+			continue
+		}
+		if got != expected {
+			t.Errorf("ColorRGBA(%q): expected %v, got %v", value, expected, got)
+		}
+	}
+
+	invalid := []string{"", "#", "#ff", "#ffff", "#fffff", "#ggg", "#1e90ff8", "##fff", "+fffff"}
+	for _, value := range invalid {
+		_, err := ColorRGBA("theme", value)
+		if err == nil {
+			t.Errorf("ColorRGBA(%q): expected error", value)
+			continue
+		}
+		if !strings.Contains(err.Error(), "theme") {
+			t.Errorf("ColorRGBA(%q): expected the error to name the key, got %v", value, err)
+		}
+	}
+}
+
+func TestDuration(t *testing.T) {
+	valid := map[string]time.Duration{
+		"5s":        5 * time.Second,
+		"1h30m":     90 * time.Minute,
+		"1d":        24 * time.Hour,
+		"1.5d":      36 * time.Hour,
+		"1w":        7 * 24 * time.Hour,
+		"1w2d":      9 * 24 * time.Hour,
+		"2d12h":     60 * time.Hour,
+		"-1d":       -24 * time.Hour,
+		"PT90M":     90 * time.Minute,
+		"PT1.5S":    1500 * time.Millisecond,
+		"P1DT12H":   36 * time.Hour,
+		"P2W":       14 * 24 * time.Hour,
+		"PT1H30M5S": time.Hour + 30*time.Minute + 5*time.Second,
+	}
+
+	for value, expected := range valid {
+		got, err := Duration(value)
+		if err != nil {
+			t.Errorf("Duration(%q) failed: %v", value, err)
+			continue
+		}
+		if got != expected {
+			t.Errorf("Duration(%q): expected %v, got %v", value, expected, got)
+		}
+	}
+
+	invalid := []string{"", "5", "d", "1x", "P", "PT", "P1Y", "P1M", "PT5", "P1H"}
+	for _, value := range invalid {
+		if _, err := Duration(value); err == nil {
+			t.Errorf("Duration(%q): expected error", value)
+		}
+	}
+}
+
+func TestDecimalCommaFloat(t *testing.T) {
+	valid := map[string]float64{
+		"3,14": 3.14,
+		"3.14": 3.14,
+		"-0,5": -0.5,
+		"42":   42,
+	}
+
+	for value, expected := range valid {
+		got, err := DecimalCommaFloat(value)
+		if err != nil {
+			t.Errorf("DecimalCommaFloat(%q) failed: %v", value, err)
+			continue
+		}
+		if got != expected {
+			t.Errorf("DecimalCommaFloat(%q): expected %v, got %v", value, expected, got)
+		}
+	}
+
+	invalid := []string{"", "1,234.5", "1.234,5", "1,2,3", "abc"}
+	for _, value := range invalid {
+		if _, err := DecimalCommaFloat(value); err == nil {
+			t.Errorf("DecimalCommaFloat(%q): expected error", value)
+		}
+	}
+
+	if _, err := Float("3,14"); err == nil {
+		t.Error("expected Float to reject a decimal comma")
+	}
+}
+
+func TestDurationMillis(t *testing.T) {
+	valid := map[string]time.Duration{
+		"5000":  5 * time.Second,
+		"5s":    5 * time.Second,
+		"0":     0,
+		"1e+06": 1000 * time.Second,
+		"250ms": 250 * time.Millisecond,
+		"1d":    24 * time.Hour,
+	}
+
+	for value, expected := range valid {
+		got, err := DurationMillis(value)
+		if err != nil {
+			t.Errorf("DurationMillis(%q) failed: %v", value, err)
+			continue
+		}
+		if got != expected {
+			t.Errorf("DurationMillis(%q): expected %v, got %v", value, expected, got)
+		}
+	}
+
+	for _, value := range []string{"", "5.5", "soon"} {
+		if _, err := DurationMillis(value); err == nil {
+			t.Errorf("DurationMillis(%q): expected error", value)
+		}
+	}
+}
+
+func TestFlatten_SkipsNull(t *testing.T) {
+	document := map[string]any{
+		"unset":  nil,
+		"nested": map[string]any{"unset": nil, "set": "x"},
+		"list":   []any{"a", nil},
+	}
+
+	got := Flatten(document)
+	for _, key := range []string{"unset", "nested.unset", "list.1"} {
+		if value, ok := got[key]; ok {
+			t.Errorf("expected %s to be left out, got %q", key, value)
+		}
+	}
+	if got["nested.set"] != "x" {
+		t.Errorf("expected nested.set to be x, got %q", got["nested.set"])
+	}
+
+	if items := StringSlice(got["list"]); !reflect.DeepEqual(items, []string{"a", ""}) {
+		t.Errorf("expected a null element to become an empty string, got %q", items)
+	}
+}
@@ -0,0 +1,513 @@
+// Package kv provides a thread-safe store of text config values with the same
+// getter semantics as the Redis manager. Backends embed *Store and swap its
+// contents with Replace.
+package kv
+
+import (
+	"fmt"
+	"image/color"
+	"net"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/zemld/config-manager/pkg/cm"
+	"github.com/zemld/config-manager/pkg/cm/internal/conv"
+)
+
+type Store struct {
+	mu         sync.RWMutex
+	values     map[string]string
+	composites map[string]bool
+	updatedAt  time.Time
+}
+
+func NewStore() *Store {
+	return &Store{
+		values: make(map[string]string),
+	}
+}
+
+// Replace swaps the contents of the store. It is a function rather than a
+// method so that it is not promoted onto the backends embedding the store.
+func Replace(s *Store, values map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.values = values
+	s.composites = nil
+	s.updatedAt = time.Now()
+}
+
+// ReplaceDocument flattens a decoded document into the store, remembering
+// which keys held objects or arrays so that GetString can refuse them.
+func ReplaceDocument(s *Store, document map[string]any) {
+	values, composites := conv.FlattenKinds(document)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.values = values
+	s.composites = composites
+	s.updatedAt = time.Now()
+}
+
+func (s *Store) LastUpdated() time.Time {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.updatedAt
+}
+
+func (s *Store) IsLoaded() bool {
+	return !s.LastUpdated().IsZero()
+}
+
+// Keys returns a sorted snapshot of all keys.
+func (s *Store) Keys() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	keys := make([]string, 0, len(s.values))
+	for key := range s.values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// Snapshot returns a copy of the stored config that callers may modify.
+func (s *Store) Snapshot() map[string]string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	snapshot := make(map[string]string, len(s.values))
+	for key, value := range s.values {
+		snapshot[key] = value
+	}
+
+	return snapshot
+}
+
+// GetByPrefix returns a copy of every key starting with prefix.
+func (s *Store) GetByPrefix(prefix string) map[string]string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	matches := make(map[string]string)
+	for key, value := range s.values {
+		if strings.HasPrefix(key, prefix) {
+			matches[key] = value
+		}
+	}
+
+	return matches
+}
+
+func (s *Store) lookup(key string) (string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	value, ok := s.values[key]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", cm.ErrKeyNotFound, key)
+	}
+
+	return value, nil
+}
+
+func (s *Store) GetInt(key string) (int, error) {
+	value, err := s.lookup(key)
+	if err != nil {
+		return 0, err
+	}
+
+	return conv.Int(value)
+}
+
+func (s *Store) GetFloat(key string) (float64, error) {
+	value, err := s.lookup(key)
+	if err != nil {
+		return 0, err
+	}
+
+	return conv.Float(value)
+}
+
+func (s *Store) GetString(key string) (string, error) {
+	// The value and its kind are read under one lock, so that a concurrent
+	// ReplaceDocument cannot pair them from different documents.
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	value, ok := s.values[key]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", cm.ErrKeyNotFound, key)
+	}
+	if s.composites[key] {
+		return "", fmt.Errorf("key %s is an object or array, not a string: %w", key, cm.ErrTypeMismatch)
+	}
+
+	return value, nil
+}
+
+// GetRaw returns the stored text of key without checking its kind, so
+// objects and arrays come back as JSON.
+func (s *Store) GetRaw(key string) (string, error) {
+	return s.lookup(key)
+}
+
+func (s *Store) GetBool(key string) (bool, error) {
+	value, err := s.lookup(key)
+	if err != nil {
+		return false, err
+	}
+
+	return conv.Bool(value)
+}
+
+func (s *Store) GetDuration(key string) (time.Duration, error) {
+	value, err := s.lookup(key)
+	if err != nil {
+		return 0, err
+	}
+
+	return conv.Duration(value)
+}
+
+func (s *Store) GetStringSlice(key string) ([]string, error) {
+	value, err := s.lookup(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return conv.StringSlice(value), nil
+}
+
+func (s *Store) GetBytes(key string) ([]byte, error) {
+	value, err := s.lookup(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return conv.Bytes(key, value)
+}
+
+func (s *Store) GetIntSlice(key string) ([]int, error) {
+	value, err := s.lookup(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return conv.IntSlice(key, value)
+}
+
+func (s *Store) GetFloatSlice(key string) ([]float64, error) {
+	value, err := s.lookup(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return conv.FloatSlice(key, value)
+}
+
+func (s *Store) GetStringMap(key string) (map[string]string, error) {
+	value, err := s.lookup(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return conv.StringMap(key, value)
+}
+
+func (s *Store) GetURL(key string) (*url.URL, error) {
+	value, err := s.lookup(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return conv.URL(key, value)
+}
+
+func (s *Store) GetEnum(key string, allowed []string) (string, error) {
+	value, err := s.lookup(key)
+	if err != nil {
+		return "", err
+	}
+
+	return conv.Enum(key, value, allowed)
+}
+
+func (s *Store) GetIP(key string) (net.IP, error) {
+	value, err := s.lookup(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return conv.IP(key, value)
+}
+
+func (s *Store) GetIPNet(key string) (*net.IPNet, error) {
+	value, err := s.lookup(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return conv.IPNet(key, value)
+}
+
+func (s *Store) GetInt64(key string) (int64, error) {
+	value, err := s.lookup(key)
+	if err != nil {
+		return 0, err
+	}
+
+	return conv.Int64(key, value)
+}
+
+func (s *Store) GetUint64(key string) (uint64, error) {
+	value, err := s.lookup(key)
+	if err != nil {
+		return 0, err
+	}
+
+	return conv.Uint64(key, value)
+}
+
+func (s *Store) GetBytesSize(key string) (int64, error) {
+	value, err := s.lookup(key)
+	if err != nil {
+		return 0, err
+	}
+
+	return conv.BytesSize(key, value)
+}
+
+func (s *Store) GetPercent(key string) (float64, error) {
+	value, err := s.lookup(key)
+	if err != nil {
+		return 0, err
+	}
+
+	return conv.Percent(key, value)
+}
+
+func (s *Store) GetStringMatching(key string, re *regexp.Regexp) (string, error) {
+	value, err := s.lookup(key)
+	if err != nil {
+		return "", err
+	}
+
+	return conv.Matching(key, value, re)
+}
+
+func (s *Store) GetDurationMillis(key string) (time.Duration, error) {
+	value, err := s.lookup(key)
+	if err != nil {
+		return 0, err
+	}
+
+	return conv.DurationMillis(value)
+}
+
+func (s *Store) GetColorRGBA(key string) (color.RGBA, error) {
+	value, err := s.lookup(key)
+	if err != nil {
+		return color.RGBA{}, err
+	}
+
+	return conv.ColorRGBA(key, value)
+}
+
+func (s *Store) GetIntWithDefault(key string, defaultValue int) int {
+	value, err := s.GetInt(key)
+	if err != nil {
+		return defaultValue
+	}
+
+	return value
+}
+
+func (s *Store) GetFloatWithDefault(key string, defaultValue float64) float64 {
+	value, err := s.GetFloat(key)
+	if err != nil {
+		return defaultValue
+	}
+
+	return value
+}
+
+func (s *Store) GetStringWithDefault(key string, defaultValue string) string {
+	value, err := s.GetString(key)
+	if err != nil {
+		return defaultValue
+	}
+
+	return value
+}
+
+func (s *Store) GetBoolWithDefault(key string, defaultValue bool) bool {
+	value, err := s.GetBool(key)
+	if err != nil {
+		return defaultValue
+	}
+
+	return value
+}
+
+func (s *Store) GetDurationWithDefault(key string, defaultValue time.Duration) time.Duration {
+	value, err := s.GetDuration(key)
+	if err != nil {
+		return defaultValue
+	}
+
+	return value
+}
+
+func (s *Store) GetStringSliceWithDefault(key string, defaultValue []string) []string {
+	value, err := s.GetStringSlice(key)
+	if err != nil {
+		return defaultValue
+	}
+
+	return value
+}
+
+func (s *Store) GetBytesWithDefault(key string, defaultValue []byte) []byte {
+	value, err := s.GetBytes(key)
+	if err != nil {
+		return defaultValue
+	}
+
+	return value
+}
+
+func (s *Store) GetIntSliceWithDefault(key string, defaultValue []int) []int {
+	value, err := s.GetIntSlice(key)
+	if err != nil {
+		return defaultValue
+	}
+
+	return value
+}
+
+func (s *Store) GetFloatSliceWithDefault(key string, defaultValue []float64) []float64 {
+	value, err := s.GetFloatSlice(key)
+	if err != nil {
+		return defaultValue
+	}
+
+	return value
+}
+
+func (s *Store) GetStringMapWithDefault(key string, defaultValue map[string]string) map[string]string {
+	value, err := s.GetStringMap(key)
+	if err != nil {
+		return defaultValue
+	}
+
+	return value
+}
+
+func (s *Store) GetURLWithDefault(key string, defaultValue *url.URL) *url.URL {
+	value, err := s.GetURL(key)
+	if err != nil {
+		return defaultValue
+	}
+
+	return value
+}
+
+func (s *Store) GetEnumWithDefault(key string, allowed []string, defaultValue string) string {
+	value, err := s.GetEnum(key, allowed)
+	if err != nil {
+		return defaultValue
+	}
+
+	return value
+}
+
+func (s *Store) GetIPWithDefault(key string, defaultValue net.IP) net.IP {
+	value, err := s.GetIP(key)
+	if err != nil {
+		return defaultValue
+	}
+
+	return value
+}
+
+func (s *Store) GetIPNetWithDefault(key string, defaultValue *net.IPNet) *net.IPNet {
+	value, err := s.GetIPNet(key)
+	if err != nil {
+		return defaultValue
+	}
+
+	return value
+}
+
+func (s *Store) GetInt64WithDefault(key string, defaultValue int64) int64 {
+	value, err := s.GetInt64(key)
+	if err != nil {
+		return defaultValue
+	}
+
+	return value
+}
+
+func (s *Store) GetUint64WithDefault(key string, defaultValue uint64) uint64 {
+	value, err := s.GetUint64(key)
+	if err != nil {
+		return defaultValue
+	}
+
+	return value
+}
+
+func (s *Store) GetBytesSizeWithDefault(key string, defaultValue int64) int64 {
+	value, err := s.GetBytesSize(key)
+	if err != nil {
+		return defaultValue
+	}
+
+	return value
+}
+
+func (s *Store) GetPercentWithDefault(key string, defaultValue float64) float64 {
+	value, err := s.GetPercent(key)
+	if err != nil {
+		return defaultValue
+	}
+
+	return value
+}
+
+func (s *Store) GetStringMatchingWithDefault(key string, re *regexp.Regexp, defaultValue string) string {
+	value, err := s.GetStringMatching(key, re)
+	if err != nil {
+		return defaultValue
+	}
+
+	return value
+}
+
+func (s *Store) GetDurationMillisWithDefault(key string, defaultValue time.Duration) time.Duration {
+	value, err := s.GetDurationMillis(key)
+	if err != nil {
+		return defaultValue
+	}
+
+	return value
+}
+
+func (s *Store) GetColorRGBAWithDefault(key string, defaultValue color.RGBA) color.RGBA {
+	value, err := s.GetColorRGBA(key)
+	if err != nil {
+		return defaultValue
+	}
+
+	return value
+}
@@ -0,0 +1,38 @@
+package cm
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// RawGetter is implemented by managers that can return the stored text of
+// any key, including objects and arrays that GetString refuses.
+type RawGetter interface {
+	GetRaw(key string) (string, error)
+}
+
+// GetJSON decodes the JSON value stored under key into a T. It suits small
+// structured values kept under a single key. Managers implementing RawGetter
+// can decode objects and arrays as well as strings holding JSON. A stale
+// value is decoded and returned together with ErrStale.
+func GetJSON[T any](m ConfigManager, key string) (T, error) {
+	var value T
+
+	var raw string
+	var err error
+	if rawGetter, ok := m.(RawGetter); ok {
+		raw, err = rawGetter.GetRaw(key)
+	} else {
+		raw, err = m.GetString(key)
+	}
+	if err != nil && !errors.Is(err, ErrStale) {
+		return value, err
+	}
+
+	if err := json.Unmarshal([]byte(raw), &value); err != nil {
+		return value, fmt.Errorf("key %s is not valid JSON for %T: %w", key, value, err)
+	}
+
+	return value, err
+}
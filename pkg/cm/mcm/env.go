@@ -0,0 +1,63 @@
+package mcm
+
+import (
+	"os"
+	"time"
+
+	"github.com/zemld/config-manager/pkg/cm/internal/conv"
+)
+
+// The OrEnv getters fall back to envVar and then defaultValue, as described
+// in the cm package documentation.
+
+func (mcm *InMemoryConfigManager) GetStringOrEnv(key, envVar, defaultValue string) string {
+	value, err := mcm.GetString(key)
+	if err == nil {
+		return value
+	}
+
+	if env := os.Getenv(envVar); env != "" {
+		return env
+	}
+
+	return defaultValue
+}
+
+func (mcm *InMemoryConfigManager) GetIntOrEnv(key, envVar string, defaultValue int) int {
+	value, err := mcm.GetInt(key)
+	if err == nil {
+		return value
+	}
+
+	if env, err := conv.Int(os.Getenv(envVar)); err == nil {
+		return env
+	}
+
+	return defaultValue
+}
+
+func (mcm *InMemoryConfigManager) GetBoolOrEnv(key, envVar string, defaultValue bool) bool {
+	value, err := mcm.GetBool(key)
+	if err == nil {
+		return value
+	}
+
+	if env, err := conv.Bool(os.Getenv(envVar)); err == nil {
+		return env
+	}
+
+	return defaultValue
+}
+
+func (mcm *InMemoryConfigManager) GetDurationOrEnv(key, envVar string, defaultValue time.Duration) time.Duration {
+	value, err := mcm.GetDuration(key)
+	if err == nil {
+		return value
+	}
+
+	if env, err := conv.Duration(os.Getenv(envVar)); err == nil {
+		return env
+	}
+
+	return defaultValue
+}
@@ -2,96 +2,623 @@ package mcm
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"image/color"
+	"net"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
+
+	"github.com/zemld/config-manager/pkg/cm"
+	"github.com/zemld/config-manager/pkg/cm/internal/bind"
+	"github.com/zemld/config-manager/pkg/cm/internal/conv"
 )
 
 type InMemoryConfigManager struct {
-	data map[string]any
+	mu        sync.RWMutex
+	data      map[string]any
+	updatedAt time.Time
+	loadErr   error
 }
 
 func NewMockConfigManager(data map[string]any) *InMemoryConfigManager {
+	if data == nil {
+		data = make(map[string]any)
+	}
+
 	return &InMemoryConfigManager{
-		data: data,
+		data:      data,
+		updatedAt: time.Now(),
 	}
 }
 
 func (mcm *InMemoryConfigManager) StartLoading(interval time.Duration) {}
 func (mcm *InMemoryConfigManager) StopLoading()                        {}
 func (mcm *InMemoryConfigManager) LoadConfig(ctx context.Context) error {
+	mcm.mu.RLock()
+	defer mcm.mu.RUnlock()
+
+	return mcm.loadErr
+}
+
+// HealthCheck always succeeds, since the in-memory manager has no backend.
+func (mcm *InMemoryConfigManager) HealthCheck(ctx context.Context) error {
 	return nil
 }
 
-func (mcm *InMemoryConfigManager) GetInt(key string) (int, error) {
+// SetLoadError makes LoadConfig return err, simulating a failing backend.
+// Pass nil to make loads succeed again.
+func (mcm *InMemoryConfigManager) SetLoadError(err error) {
+	mcm.mu.Lock()
+	defer mcm.mu.Unlock()
+
+	mcm.loadErr = err
+}
+
+// SetLastUpdated overrides the time reported by LastUpdated, simulating a
+// config that has not been refreshed since t.
+func (mcm *InMemoryConfigManager) SetLastUpdated(t time.Time) {
+	mcm.mu.Lock()
+	defer mcm.mu.Unlock()
+
+	mcm.updatedAt = t
+}
+
+// LastUpdated returns the time the manager was created or last Set.
+func (mcm *InMemoryConfigManager) LastUpdated() time.Time {
+	mcm.mu.RLock()
+	defer mcm.mu.RUnlock()
+
+	return mcm.updatedAt
+}
+
+// IsLoaded always returns true: the data passed to NewMockConfigManager
+// counts as loaded.
+func (mcm *InMemoryConfigManager) IsLoaded() bool {
+	return true
+}
+
+// Set stores value under key. It is safe to call while other goroutines read.
+func (mcm *InMemoryConfigManager) Set(key string, value any) {
+	mcm.mu.Lock()
+	defer mcm.mu.Unlock()
+
+	mcm.data[key] = value
+	mcm.updatedAt = time.Now()
+}
+
+// Keys returns a sorted snapshot of all keys.
+func (mcm *InMemoryConfigManager) Keys() []string {
+	mcm.mu.RLock()
+	defer mcm.mu.RUnlock()
+
+	keys := make([]string, 0, len(mcm.data))
+	for key := range mcm.data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// Snapshot returns a deep copy of the config data. Slices and maps are copied
+// recursively so callers cannot mutate the manager's state.
+func (mcm *InMemoryConfigManager) Snapshot() map[string]any {
+	mcm.mu.RLock()
+	defer mcm.mu.RUnlock()
+
+	snapshot := make(map[string]any, len(mcm.data))
+	for key, value := range mcm.data {
+		snapshot[key] = deepCopy(value)
+	}
+
+	return snapshot
+}
+
+// GetByPrefix returns every key starting with prefix with its value in text
+// form, as the other backends would store it.
+func (mcm *InMemoryConfigManager) GetByPrefix(prefix string) map[string]string {
+	mcm.mu.RLock()
+	defer mcm.mu.RUnlock()
+
+	matches := make(map[string]string)
+	for key, value := range mcm.data {
+		if strings.HasPrefix(key, prefix) {
+			matches[key] = conv.Stringify(value)
+		}
+	}
+
+	return matches
+}
+
+func deepCopy(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		copied := make(map[string]any, len(v))
+		for key, item := range v {
+			copied[key] = deepCopy(item)
+		}
+		return copied
+	case map[string]string:
+		copied := make(map[string]string, len(v))
+		for key, item := range v {
+			copied[key] = item
+		}
+		return copied
+	case []any:
+		copied := make([]any, len(v))
+		for i, item := range v {
+			copied[i] = deepCopy(item)
+		}
+		return copied
+	case []string:
+		return append([]string(nil), v...)
+	case []int:
+		return append([]int(nil), v...)
+	case []float64:
+		return append([]float64(nil), v...)
+	case []byte:
+		return append([]byte(nil), v...)
+	}
+
+	return value
+}
+
+func (mcm *InMemoryConfigManager) get(key string) (any, bool) {
+	mcm.mu.RLock()
+	defer mcm.mu.RUnlock()
+
 	value, ok := mcm.data[key]
+	return value, ok
+}
+
+// Unmarshal fills the struct v points to from the config data. Fields are
+// matched to keys by their cm tag or, without one, by field name.
+func (mcm *InMemoryConfigManager) Unmarshal(v any) error {
+	return bind.Struct(mcm, mcm.has, v)
+}
+
+func (mcm *InMemoryConfigManager) has(key string) bool {
+	_, ok := mcm.get(key)
+	return ok
+}
+
+func (mcm *InMemoryConfigManager) GetInt(key string) (int, error) {
+	value, ok := mcm.get(key)
 	if !ok {
-		return 0, fmt.Errorf("key %s not found", key)
+		return 0, fmt.Errorf("%w: %s", cm.ErrKeyNotFound, key)
 	}
 
 	intValue, ok := value.(int)
 	if !ok {
-		return 0, fmt.Errorf("key %s is not an int", key)
+		return 0, fmt.Errorf("key %s is not an int: %w", key, cm.ErrTypeMismatch)
 	}
 
 	return intValue, nil
 }
 
 func (mcm *InMemoryConfigManager) GetFloat(key string) (float64, error) {
-	value, ok := mcm.data[key]
+	value, ok := mcm.get(key)
 	if !ok {
-		return 0, fmt.Errorf("key %s not found", key)
+		return 0, fmt.Errorf("%w: %s", cm.ErrKeyNotFound, key)
 	}
 
 	floatValue, ok := value.(float64)
 	if !ok {
-		return 0, fmt.Errorf("key %s is not a float", key)
+		return 0, fmt.Errorf("key %s is not a float: %w", key, cm.ErrTypeMismatch)
 	}
 
 	return floatValue, nil
 }
 
 func (mcm *InMemoryConfigManager) GetString(key string) (string, error) {
-	value, ok := mcm.data[key]
+	value, ok := mcm.get(key)
 	if !ok {
-		return "", fmt.Errorf("key %s not found", key)
+		return "", fmt.Errorf("%w: %s", cm.ErrKeyNotFound, key)
 	}
 
 	stringValue, ok := value.(string)
 	if !ok {
-		return "", fmt.Errorf("key %s is not a string", key)
+		return "", fmt.Errorf("key %s is not a string: %w", key, cm.ErrTypeMismatch)
 	}
 
 	return stringValue, nil
 }
 
+// GetValue returns the stored value of key as it was set.
+func (mcm *InMemoryConfigManager) GetValue(key string) (any, error) {
+	value, ok := mcm.get(key)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", cm.ErrKeyNotFound, key)
+	}
+
+	return value, nil
+}
+
+// GetRaw returns string values as they are and encodes every other value as
+// JSON, so GetJSON can decode maps and slices stored directly.
+func (mcm *InMemoryConfigManager) GetRaw(key string) (string, error) {
+	value, ok := mcm.get(key)
+	if !ok {
+		return "", fmt.Errorf("%w: %s", cm.ErrKeyNotFound, key)
+	}
+
+	if stringValue, ok := value.(string); ok {
+		return stringValue, nil
+	}
+
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return "", fmt.Errorf("key %s cannot be encoded as JSON: %w", key, err)
+	}
+
+	return string(raw), nil
+}
+
 func (mcm *InMemoryConfigManager) GetBool(key string) (bool, error) {
-	value, ok := mcm.data[key]
+	value, ok := mcm.get(key)
 	if !ok {
-		return false, fmt.Errorf("key %s not found", key)
+		return false, fmt.Errorf("%w: %s", cm.ErrKeyNotFound, key)
 	}
 
 	boolValue, ok := value.(bool)
 	if !ok {
-		return false, fmt.Errorf("key %s is not a bool", key)
+		return false, fmt.Errorf("key %s is not a bool: %w", key, cm.ErrTypeMismatch)
 	}
 
 	return boolValue, nil
 }
 
 func (mcm *InMemoryConfigManager) GetDuration(key string) (time.Duration, error) {
-	value, ok := mcm.data[key]
+	value, ok := mcm.get(key)
 	if !ok {
-		return 0, fmt.Errorf("key %s not found", key)
+		return 0, fmt.Errorf("%w: %s", cm.ErrKeyNotFound, key)
 	}
 
 	durationValue, ok := value.(time.Duration)
 	if !ok {
-		return 0, fmt.Errorf("key %s is not a duration", key)
+		return 0, fmt.Errorf("key %s is not a duration: %w", key, cm.ErrTypeMismatch)
 	}
 
 	return durationValue, nil
 }
 
+func (mcm *InMemoryConfigManager) GetStringSlice(key string) ([]string, error) {
+	value, ok := mcm.get(key)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", cm.ErrKeyNotFound, key)
+	}
+
+	switch sliceValue := value.(type) {
+	case []string:
+		return sliceValue, nil
+	case []any:
+		result := make([]string, 0, len(sliceValue))
+		for _, item := range sliceValue {
+			result = append(result, fmt.Sprintf("%v", item))
+		}
+		return result, nil
+	}
+
+	return nil, fmt.Errorf("key %s is not a string slice: %w", key, cm.ErrTypeMismatch)
+}
+
+func (mcm *InMemoryConfigManager) GetBytes(key string) ([]byte, error) {
+	value, ok := mcm.get(key)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", cm.ErrKeyNotFound, key)
+	}
+
+	switch bytesValue := value.(type) {
+	case []byte:
+		return bytesValue, nil
+	case string:
+		return []byte(bytesValue), nil
+	}
+
+	return nil, fmt.Errorf("key %s is not bytes: %w", key, cm.ErrTypeMismatch)
+}
+
+func (mcm *InMemoryConfigManager) GetIntContext(ctx context.Context, key string) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	return mcm.GetInt(key)
+}
+
+func (mcm *InMemoryConfigManager) GetFloatContext(ctx context.Context, key string) (float64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	return mcm.GetFloat(key)
+}
+
+func (mcm *InMemoryConfigManager) GetStringContext(ctx context.Context, key string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	return mcm.GetString(key)
+}
+
+func (mcm *InMemoryConfigManager) GetBoolContext(ctx context.Context, key string) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	return mcm.GetBool(key)
+}
+
+func (mcm *InMemoryConfigManager) GetDurationContext(ctx context.Context, key string) (time.Duration, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	return mcm.GetDuration(key)
+}
+
+func (mcm *InMemoryConfigManager) GetStringSliceContext(ctx context.Context, key string) ([]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return mcm.GetStringSlice(key)
+}
+
+func (mcm *InMemoryConfigManager) GetBytesContext(ctx context.Context, key string) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return mcm.GetBytes(key)
+}
+
+func (mcm *InMemoryConfigManager) GetIntSlice(key string) ([]int, error) {
+	value, ok := mcm.get(key)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", cm.ErrKeyNotFound, key)
+	}
+
+	switch sliceValue := value.(type) {
+	case []int:
+		return sliceValue, nil
+	case []any:
+		result := make([]int, 0, len(sliceValue))
+		for i, item := range sliceValue {
+			intValue, ok := item.(int)
+			if !ok {
+				return nil, fmt.Errorf("key %s: element %d is not an int: %w", key, i, cm.ErrTypeMismatch)
+			}
+			result = append(result, intValue)
+		}
+		return result, nil
+	}
+
+	return nil, fmt.Errorf("key %s is not an int slice: %w", key, cm.ErrTypeMismatch)
+}
+
+func (mcm *InMemoryConfigManager) GetFloatSlice(key string) ([]float64, error) {
+	value, ok := mcm.get(key)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", cm.ErrKeyNotFound, key)
+	}
+
+	switch sliceValue := value.(type) {
+	case []float64:
+		return sliceValue, nil
+	case []any:
+		result := make([]float64, 0, len(sliceValue))
+		for i, item := range sliceValue {
+			switch number := item.(type) {
+			case float64:
+				result = append(result, number)
+			case int:
+				result = append(result, float64(number))
+			default:
+				return nil, fmt.Errorf("key %s: element %d is not a float: %w", key, i, cm.ErrTypeMismatch)
+			}
+		}
+		return result, nil
+	}
+
+	return nil, fmt.Errorf("key %s is not a float slice: %w", key, cm.ErrTypeMismatch)
+}
+
+func (mcm *InMemoryConfigManager) GetStringMap(key string) (map[string]string, error) {
+	value, ok := mcm.get(key)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", cm.ErrKeyNotFound, key)
+	}
+
+	switch mapValue := value.(type) {
+	case map[string]string:
+		return mapValue, nil
+	case map[string]any:
+		result := make(map[string]string, len(mapValue))
+		for mapKey, item := range mapValue {
+			result[mapKey] = fmt.Sprintf("%v", item)
+		}
+		return result, nil
+	}
+
+	return nil, fmt.Errorf("key %s is not a string map: %w", key, cm.ErrTypeMismatch)
+}
+
+func (mcm *InMemoryConfigManager) GetURL(key string) (*url.URL, error) {
+	value, ok := mcm.get(key)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", cm.ErrKeyNotFound, key)
+	}
+
+	switch urlValue := value.(type) {
+	case *url.URL:
+		return urlValue, nil
+	case string:
+		return conv.URL(key, urlValue)
+	}
+
+	return nil, fmt.Errorf("key %s is not a url: %w", key, cm.ErrTypeMismatch)
+}
+
+func (mcm *InMemoryConfigManager) GetEnum(key string, allowed []string) (string, error) {
+	value, err := mcm.GetString(key)
+	if err != nil {
+		return "", err
+	}
+
+	return conv.Enum(key, value, allowed)
+}
+
+func (mcm *InMemoryConfigManager) GetIP(key string) (net.IP, error) {
+	value, ok := mcm.get(key)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", cm.ErrKeyNotFound, key)
+	}
+
+	switch typedValue := value.(type) {
+	case net.IP:
+		return typedValue, nil
+	case string:
+		return conv.IP(key, typedValue)
+	}
+
+	return nil, fmt.Errorf("key %s is not an ip address: %w", key, cm.ErrTypeMismatch)
+}
+
+func (mcm *InMemoryConfigManager) GetIPNet(key string) (*net.IPNet, error) {
+	value, ok := mcm.get(key)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", cm.ErrKeyNotFound, key)
+	}
+
+	switch typedValue := value.(type) {
+	case *net.IPNet:
+		return typedValue, nil
+	case string:
+		return conv.IPNet(key, typedValue)
+	}
+
+	return nil, fmt.Errorf("key %s is not a cidr range: %w", key, cm.ErrTypeMismatch)
+}
+
+func (mcm *InMemoryConfigManager) GetInt64(key string) (int64, error) {
+	value, ok := mcm.get(key)
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", cm.ErrKeyNotFound, key)
+	}
+
+	switch typedValue := value.(type) {
+	case int64:
+		return typedValue, nil
+	case int:
+		return int64(typedValue), nil
+	}
+
+	return 0, fmt.Errorf("key %s is not an int64: %w", key, cm.ErrTypeMismatch)
+}
+
+func (mcm *InMemoryConfigManager) GetUint64(key string) (uint64, error) {
+	value, ok := mcm.get(key)
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", cm.ErrKeyNotFound, key)
+	}
+
+	switch typedValue := value.(type) {
+	case uint64:
+		return typedValue, nil
+	case uint:
+		return uint64(typedValue), nil
+	}
+
+	return 0, fmt.Errorf("key %s is not a uint64: %w", key, cm.ErrTypeMismatch)
+}
+
+func (mcm *InMemoryConfigManager) GetBytesSize(key string) (int64, error) {
+	value, ok := mcm.get(key)
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", cm.ErrKeyNotFound, key)
+	}
+
+	switch size := value.(type) {
+	case int64:
+		return size, nil
+	case int:
+		return int64(size), nil
+	case string:
+		return conv.BytesSize(key, size)
+	}
+
+	return 0, fmt.Errorf("key %s is not a size: %w", key, cm.ErrTypeMismatch)
+}
+
+func (mcm *InMemoryConfigManager) GetPercent(key string) (float64, error) {
+	value, ok := mcm.get(key)
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", cm.ErrKeyNotFound, key)
+	}
+
+	switch percent := value.(type) {
+	case float64:
+		return conv.Percent(key, strconv.FormatFloat(percent, 'g', -1, 64))
+	case string:
+		return conv.Percent(key, percent)
+	}
+
+	return 0, fmt.Errorf("key %s is not a percentage: %w", key, cm.ErrTypeMismatch)
+}
+
+func (mcm *InMemoryConfigManager) GetStringMatching(key string, re *regexp.Regexp) (string, error) {
+	value, err := mcm.GetString(key)
+	if err != nil {
+		return "", err
+	}
+
+	return conv.Matching(key, value, re)
+}
+
+func (mcm *InMemoryConfigManager) GetDurationMillis(key string) (time.Duration, error) {
+	value, ok := mcm.get(key)
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", cm.ErrKeyNotFound, key)
+	}
+
+	switch typedValue := value.(type) {
+	case time.Duration:
+		return typedValue, nil
+	case int:
+		return time.Duration(typedValue) * time.Millisecond, nil
+	case int64:
+		return time.Duration(typedValue) * time.Millisecond, nil
+	case string:
+		return conv.DurationMillis(typedValue)
+	}
+
+	return 0, fmt.Errorf("key %s is not a duration: %w", key, cm.ErrTypeMismatch)
+}
+
+func (mcm *InMemoryConfigManager) GetColorRGBA(key string) (color.RGBA, error) {
+	value, ok := mcm.get(key)
+	if !ok {
+		return color.RGBA{}, fmt.Errorf("%w: %s", cm.ErrKeyNotFound, key)
+	}
+
+	switch typedValue := value.(type) {
+	case color.RGBA:
+		return typedValue, nil
+	case string:
+		return conv.ColorRGBA(key, typedValue)
+	}
+
+	return color.RGBA{}, fmt.Errorf("key %s is not a color: %w", key, cm.ErrTypeMismatch)
+}
+
 func (mcm *InMemoryConfigManager) GetIntWithDefault(key string, defaultValue int) int {
 	value, err := mcm.GetInt(key)
 	if err != nil {
@@ -136,3 +663,147 @@ func (mcm *InMemoryConfigManager) GetDurationWithDefault(key string, defaultValu
 
 	return value
 }
+
+func (mcm *InMemoryConfigManager) GetStringSliceWithDefault(key string, defaultValue []string) []string {
+	value, err := mcm.GetStringSlice(key)
+	if err != nil {
+		return defaultValue
+	}
+
+	return value
+}
+
+func (mcm *InMemoryConfigManager) GetBytesWithDefault(key string, defaultValue []byte) []byte {
+	value, err := mcm.GetBytes(key)
+	if err != nil {
+		return defaultValue
+	}
+
+	return value
+}
+
+func (mcm *InMemoryConfigManager) GetIntSliceWithDefault(key string, defaultValue []int) []int {
+	value, err := mcm.GetIntSlice(key)
+	if err != nil {
+		return defaultValue
+	}
+
+	return value
+}
+
+func (mcm *InMemoryConfigManager) GetFloatSliceWithDefault(key string, defaultValue []float64) []float64 {
+	value, err := mcm.GetFloatSlice(key)
+	if err != nil {
+		return defaultValue
+	}
+
+	return value
+}
+
+func (mcm *InMemoryConfigManager) GetStringMapWithDefault(key string, defaultValue map[string]string) map[string]string {
+	value, err := mcm.GetStringMap(key)
+	if err != nil {
+		return defaultValue
+	}
+
+	return value
+}
+
+func (mcm *InMemoryConfigManager) GetURLWithDefault(key string, defaultValue *url.URL) *url.URL {
+	value, err := mcm.GetURL(key)
+	if err != nil {
+		return defaultValue
+	}
+
+	return value
+}
+
+func (mcm *InMemoryConfigManager) GetEnumWithDefault(key string, allowed []string, defaultValue string) string {
+	value, err := mcm.GetEnum(key, allowed)
+	if err != nil {
+		return defaultValue
+	}
+
+	return value
+}
+
+func (mcm *InMemoryConfigManager) GetIPWithDefault(key string, defaultValue net.IP) net.IP {
+	value, err := mcm.GetIP(key)
+	if err != nil {
+		return defaultValue
+	}
+
+	return value
+}
+
+func (mcm *InMemoryConfigManager) GetIPNetWithDefault(key string, defaultValue *net.IPNet) *net.IPNet {
+	value, err := mcm.GetIPNet(key)
+	if err != nil {
+		return defaultValue
+	}
+
+	return value
+}
+
+func (mcm *InMemoryConfigManager) GetInt64WithDefault(key string, defaultValue int64) int64 {
+	value, err := mcm.GetInt64(key)
+	if err != nil {
+		return defaultValue
+	}
+
+	return value
+}
+
+func (mcm *InMemoryConfigManager) GetUint64WithDefault(key string, defaultValue uint64) uint64 {
+	value, err := mcm.GetUint64(key)
+	if err != nil {
+		return defaultValue
+	}
+
+	return value
+}
+
+func (mcm *InMemoryConfigManager) GetBytesSizeWithDefault(key string, defaultValue int64) int64 {
+	value, err := mcm.GetBytesSize(key)
+	if err != nil {
+		return defaultValue
+	}
+
+	return value
+}
+
+func (mcm *InMemoryConfigManager) GetPercentWithDefault(key string, defaultValue float64) float64 {
+	value, err := mcm.GetPercent(key)
+	if err != nil {
+		return defaultValue
+	}
+
+	return value
+}
+
+func (mcm *InMemoryConfigManager) GetStringMatchingWithDefault(key string, re *regexp.Regexp, defaultValue string) string {
+	value, err := mcm.GetStringMatching(key, re)
+	if err != nil {
+		return defaultValue
+	}
+
+	return value
+}
+
+func (mcm *InMemoryConfigManager) GetDurationMillisWithDefault(key string, defaultValue time.Duration) time.Duration {
+	value, err := mcm.GetDurationMillis(key)
+	if err != nil {
+		return defaultValue
+	}
+
+	return value
+}
+
+func (mcm *InMemoryConfigManager) GetColorRGBAWithDefault(key string, defaultValue color.RGBA) color.RGBA {
+	value, err := mcm.GetColorRGBA(key)
+	if err != nil {
+		return defaultValue
+	}
+
+	return value
+}
@@ -0,0 +1,171 @@
+package mcm
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/zemld/config-manager/pkg/cm"
+)
+
+func TestSet(t *testing.T) {
+	mcm := NewMockConfigManager(nil)
+
+	if _, err := mcm.GetInt("int_key"); err == nil {
+		t.Error("expected error for nonexistent key")
+	}
+
+	before := mcm.LastUpdated()
+	mcm.Set("int_key", 42)
+
+	value, err := mcm.GetInt("int_key")
+	if err != nil {
+		t.Fatalf("GetInt failed: %v", err)
+	}
+	if value != 42 {
+		t.Errorf("expected 42, got %d", value)
+	}
+
+	if mcm.LastUpdated().Before(before) {
+		t.Error("expected LastUpdated to advance after Set")
+	}
+}
+
+func TestConcurrentAccess(t *testing.T) {
+	mcm := NewMockConfigManager(map[string]any{"int_key": 0})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				mcm.Set("int_key", j)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				_, _ = mcm.GetInt("int_key")
+				_ = mcm.GetStringWithDefault("string_key", "default")
+			}
+		}()
+	}
+
+	wg.Wait()
+}
+
+func TestGetters_SentinelErrors(t *testing.T) {
+	mcm := NewMockConfigManager(map[string]any{"string_key": "value"})
+
+	if _, err := mcm.GetString("nonexistent_key"); !errors.Is(err, cm.ErrKeyNotFound) {
+		t.Errorf("expected ErrKeyNotFound, got %v", err)
+	}
+
+	if _, err := mcm.GetInt("string_key"); !errors.Is(err, cm.ErrTypeMismatch) {
+		t.Errorf("expected ErrTypeMismatch, got %v", err)
+	}
+}
+
+func TestSnapshot(t *testing.T) {
+	mcm := NewMockConfigManager(map[string]any{
+		"list":   []string{"a", "b"},
+		"nested": map[string]any{"items": []any{"x"}},
+	})
+
+	snapshot := mcm.Snapshot()
+	snapshot["list"].([]string)[0] = "mutated"
+	snapshot["nested"].(map[string]any)["items"].([]any)[0] = "mutated"
+
+	list, _ := mcm.GetStringSlice("list")
+	if list[0] != "a" {
+		t.Errorf("mutating the snapshot changed the config: got %v", list)
+	}
+
+	if mcm.Snapshot()["nested"].(map[string]any)["items"].([]any)[0] != "x" {
+		t.Error("mutating a nested snapshot value changed the config")
+	}
+}
+
+func TestSnapshot_NumericSlices(t *testing.T) {
+	mcm := NewMockConfigManager(map[string]any{
+		"ports":   []int{80, 443},
+		"weights": []float64{0.5, 1.5},
+	})
+
+	snapshot := mcm.Snapshot()
+	snapshot["ports"].([]int)[0] = 0
+	snapshot["weights"].([]float64)[0] = 0
+
+	if ports, _ := mcm.GetIntSlice("ports"); ports[0] != 80 {
+		t.Errorf("mutating the snapshot changed the int slice: got %v", ports)
+	}
+	if weights, _ := mcm.GetFloatSlice("weights"); weights[0] != 0.5 {
+		t.Errorf("mutating the snapshot changed the float slice: got %v", weights)
+	}
+}
+
+func TestSnapshot_StringMap(t *testing.T) {
+	mcm := NewMockConfigManager(map[string]any{
+		"labels": map[string]string{"env": "prod"},
+	})
+
+	mcm.Snapshot()["labels"].(map[string]string)["env"] = "mutated"
+
+	if labels, _ := mcm.GetStringMap("labels"); labels["env"] != "prod" {
+		t.Errorf("mutating the snapshot changed the string map: got %v", labels)
+	}
+}
+
+func TestMustGetters(t *testing.T) {
+	mcm := NewMockConfigManager(map[string]any{
+		"port": 8080,
+		"name": "service",
+	})
+
+	if port := mcm.MustGetInt("port"); port != 8080 {
+		t.Errorf("expected 8080, got %d", port)
+	}
+	if name := mcm.MustGetString("name"); name != "service" {
+		t.Errorf("expected service, got %s", name)
+	}
+
+	tests := []struct {
+		name string
+		call func()
+	}{
+		{"missing key", func() { mcm.MustGetString("missing") }},
+		{"type mismatch", func() { mcm.MustGetBool("name") }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("expected a panic")
+				}
+			}()
+			tt.call()
+		})
+	}
+}
+
+func TestGetOrEnv(t *testing.T) {
+	mcm := NewMockConfigManager(map[string]any{"host": "from-config", "port": 8080})
+	t.Setenv("TEST_HOST", "from-env")
+	t.Setenv("TEST_TIMEOUT", "5s")
+
+	if value := mcm.GetStringOrEnv("host", "TEST_HOST", "default"); value != "from-config" {
+		t.Errorf("expected the config to win, got %q", value)
+	}
+	if value := mcm.GetStringOrEnv("missing", "TEST_HOST", "default"); value != "from-env" {
+		t.Errorf("expected the environment variable, got %q", value)
+	}
+	if value := mcm.GetIntOrEnv("port", "TEST_PORT", 1); value != 8080 {
+		t.Errorf("expected 8080 from the config, got %d", value)
+	}
+	if value := mcm.GetDurationOrEnv("timeout", "TEST_TIMEOUT", time.Second); value != 5*time.Second {
+		t.Errorf("expected 5s from the environment, got %v", value)
+	}
+}
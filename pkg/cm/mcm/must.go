@@ -0,0 +1,55 @@
+package mcm
+
+import (
+	"time"
+
+	"github.com/zemld/config-manager/pkg/cm/internal/conv"
+)
+
+// The MustGet methods panic where the getters would return an error; see
+// the cm package documentation.
+
+func (mcm *InMemoryConfigManager) MustGetString(key string) string {
+	value, err := mcm.GetString(key)
+	if err != nil {
+		panic(conv.MustMessage(key, err))
+	}
+
+	return value
+}
+
+func (mcm *InMemoryConfigManager) MustGetInt(key string) int {
+	value, err := mcm.GetInt(key)
+	if err != nil {
+		panic(conv.MustMessage(key, err))
+	}
+
+	return value
+}
+
+func (mcm *InMemoryConfigManager) MustGetFloat(key string) float64 {
+	value, err := mcm.GetFloat(key)
+	if err != nil {
+		panic(conv.MustMessage(key, err))
+	}
+
+	return value
+}
+
+func (mcm *InMemoryConfigManager) MustGetBool(key string) bool {
+	value, err := mcm.GetBool(key)
+	if err != nil {
+		panic(conv.MustMessage(key, err))
+	}
+
+	return value
+}
+
+func (mcm *InMemoryConfigManager) MustGetDuration(key string) time.Duration {
+	value, err := mcm.GetDuration(key)
+	if err != nil {
+		panic(conv.MustMessage(key, err))
+	}
+
+	return value
+}
@@ -0,0 +1,20 @@
+package rcm
+
+import "time"
+
+// AuditSink receives a record of every config change the manager applies,
+// for example to keep a compliance trail in a database or log.
+type AuditSink interface {
+	// RecordLoad is called after a load that changed the config, with the
+	// time the new config took effect and the sorted changed keys.
+	RecordLoad(updatedAt time.Time, changed []string)
+}
+
+// WithAuditSink makes the manager report every applied change to sink.
+// Records are delivered one at a time in the order the changes were
+// applied, so a slow sink delays the next load.
+func WithAuditSink(sink AuditSink) Option {
+	return func(rcm *RedisConfigManager) {
+		rcm.audit = sink
+	}
+}
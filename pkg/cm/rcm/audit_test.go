@@ -0,0 +1,65 @@
+package rcm
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+type auditRecord struct {
+	updatedAt time.Time
+	changed   []string
+}
+
+type fakeAuditSink struct {
+	mu      sync.Mutex
+	records []auditRecord
+}
+
+func (s *fakeAuditSink) RecordLoad(updatedAt time.Time, changed []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records = append(s.records, auditRecord{updatedAt: updatedAt, changed: changed})
+}
+
+func TestWithAuditSink(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	sink := &fakeAuditSink{}
+	rcm := NewRedisConfigManagerWithClient("myservice", client, WithAuditSink(sink))
+	ctx := context.Background()
+
+	if err := mr.Set("myservice", `{"a": "1", "b": "2"}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+	if err := rcm.LoadConfig(ctx); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if err := rcm.LoadConfig(ctx); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	if err := mr.Set("myservice", `{"a": "1", "b": "3"}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+	if err := rcm.LoadConfig(ctx); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	if len(sink.records) != 2 {
+		t.Fatalf("expected a record for each changing load, got %d", len(sink.records))
+	}
+	if expected := []string{"a", "b"}; !reflect.DeepEqual(sink.records[0].changed, expected) {
+		t.Errorf("expected %v, got %v", expected, sink.records[0].changed)
+	}
+	if expected := []string{"b"}; !reflect.DeepEqual(sink.records[1].changed, expected) {
+		t.Errorf("expected %v, got %v", expected, sink.records[1].changed)
+	}
+	if !sink.records[1].updatedAt.Equal(rcm.LastUpdated()) {
+		t.Errorf("expected the record time to match LastUpdated, got %v", sink.records[1].updatedAt)
+	}
+}
@@ -0,0 +1,57 @@
+package rcm
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/zemld/config-manager/pkg/cm"
+	"github.com/zemld/config-manager/pkg/cm/internal/conv"
+)
+
+// GetStrings reads several keys from one snapshot of the config. Every key
+// that is missing, or that holds an object or array as GetString refuses, is
+// listed in the returned error; the map still holds the values that were
+// found.
+func (rcm *RedisConfigManager) GetStrings(keys ...string) (map[string]string, error) {
+	view := rcm.view()
+
+	values := make(map[string]string, len(keys))
+	var errs []error
+	for _, key := range keys {
+		value, ok := view.value(key)
+		if !ok {
+			errs = append(errs, fmt.Errorf("%w: %s", cm.ErrKeyNotFound, key))
+			continue
+		}
+		if view.composite(key) {
+			errs = append(errs, fmt.Errorf("key %s is an object or array, not a string: %w", key, cm.ErrTypeMismatch))
+			continue
+		}
+		values[key] = value
+	}
+
+	return values, rcm.checkAge(errors.Join(errs...))
+}
+
+// GetInts works like GetStrings and also lists keys whose values are not
+// integers in the returned error.
+func (rcm *RedisConfigManager) GetInts(keys ...string) (map[string]int, error) {
+	view := rcm.view()
+
+	values := make(map[string]int, len(keys))
+	var errs []error
+	for _, key := range keys {
+		value, err := parseCached(view, key, "int", conv.Int)
+		if errors.Is(err, cm.ErrKeyNotFound) {
+			errs = append(errs, err)
+			continue
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("key %s: %w", key, err))
+			continue
+		}
+		values[key] = value
+	}
+
+	return values, rcm.checkAge(errors.Join(errs...))
+}
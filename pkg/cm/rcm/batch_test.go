@@ -0,0 +1,83 @@
+package rcm
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/zemld/config-manager/pkg/cm"
+)
+
+func TestGetStringsAndInts(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	serviceName := "test_service"
+	if err := mr.Set(serviceName, `{"host": "localhost", "port": 8080, "workers": 4, "mode": "fast"}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+
+	rcm := NewRedisConfigManagerWithClient(serviceName, client).(*RedisConfigManager)
+	if err := rcm.LoadConfig(context.Background()); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	strs, err := rcm.GetStrings("host", "port")
+	if err != nil {
+		t.Fatalf("GetStrings failed: %v", err)
+	}
+	if expected := map[string]string{"host": "localhost", "port": "8080"}; !reflect.DeepEqual(strs, expected) {
+		t.Errorf("expected %v, got %v", expected, strs)
+	}
+
+	strs, err = rcm.GetStrings("host", "user", "password")
+	if !errors.Is(err, cm.ErrKeyNotFound) || !strings.Contains(err.Error(), "user") || !strings.Contains(err.Error(), "password") {
+		t.Errorf("expected both missing keys in the error, got %v", err)
+	}
+	if strs["host"] != "localhost" {
+		t.Errorf("expected found values to be returned alongside the error, got %v", strs)
+	}
+
+	ints, err := rcm.GetInts("port", "workers")
+	if err != nil {
+		t.Fatalf("GetInts failed: %v", err)
+	}
+	if expected := map[string]int{"port": 8080, "workers": 4}; !reflect.DeepEqual(ints, expected) {
+		t.Errorf("expected %v, got %v", expected, ints)
+	}
+
+	ints, err = rcm.GetInts("port", "mode", "workers")
+	if err == nil || !strings.Contains(err.Error(), "mode") {
+		t.Errorf("expected the error to name the non-integer key, got %v", err)
+	}
+	if expected := map[string]int{"port": 8080, "workers": 4}; !reflect.DeepEqual(ints, expected) {
+		t.Errorf("expected the integer values alongside the error, got %v", ints)
+	}
+}
+
+func TestGetStrings_CompositeKeys(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	serviceName := "test_service"
+	if err := mr.Set(serviceName, `{"host": "localhost", "db": {"user": "app"}, "tags": ["a"]}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+
+	rcm := NewRedisConfigManagerWithClient(serviceName, client).(*RedisConfigManager)
+	if err := rcm.LoadConfig(context.Background()); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	strs, err := rcm.GetStrings("host", "db", "tags", "db.user")
+	if !errors.Is(err, cm.ErrTypeMismatch) || !strings.Contains(err.Error(), "db") || !strings.Contains(err.Error(), "tags") {
+		t.Errorf("expected both composite keys in the error, got %v", err)
+	}
+	if expected := map[string]string{"host": "localhost", "db.user": "app"}; !reflect.DeepEqual(strs, expected) {
+		t.Errorf("expected only the scalar values, got %v", strs)
+	}
+}
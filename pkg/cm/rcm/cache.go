@@ -0,0 +1,58 @@
+package rcm
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/zemld/config-manager/pkg/cm"
+)
+
+// WithParsedCache makes the scalar getters remember parsed values until the
+// next load replaces the config, so hot keys are parsed only once per load.
+func WithParsedCache(enabled bool) Option {
+	return func(rcm *RedisConfigManager) {
+		rcm.cacheParsed = enabled
+	}
+}
+
+type cacheKey struct {
+	key  string
+	kind string
+}
+
+// parseCached looks up key in v and parses it, consulting the parsed value
+// cache of v when it is enabled. Only successful parses are cached. Since
+// every view has its own cache, a parse from a view that a load has since
+// replaced cannot leak into the current one.
+func parseCached[T any](v *view, key, kind string, parse func(string) (T, error)) (T, error) {
+	value, ok := v.value(key)
+	if !ok {
+		var zero T
+		return zero, fmt.Errorf("%w: %s", cm.ErrKeyNotFound, key)
+	}
+
+	if v.parsed == nil {
+		return parse(value)
+	}
+
+	ck := cacheKey{key: key, kind: kind}
+	if cached, ok := v.parsed.Load(ck); ok {
+		return cached.(T), nil
+	}
+
+	parsed, err := parse(value)
+	if err != nil {
+		return parsed, err
+	}
+	v.parsed.Store(ck, parsed)
+
+	return parsed, nil
+}
+
+// resetParsedCache drops every cached value. The caller must hold the write
+// lock.
+func (rcm *RedisConfigManager) resetParsedCache() {
+	if rcm.cacheParsed {
+		rcm.parsed = new(sync.Map)
+	}
+}
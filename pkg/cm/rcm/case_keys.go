@@ -0,0 +1,61 @@
+package rcm
+
+import (
+	"sort"
+	"strings"
+)
+
+// WithCaseInsensitiveKeys makes key lookups ignore case, so GetInt("MaxConns")
+// and GetInt("maxconns") read the same value. Keys are lowercased when the
+// config, overrides and defaults are stored and again on every lookup. When
+// two keys differ only in case, a warning is logged and the one sorting last
+// wins. The default is case-sensitive.
+func WithCaseInsensitiveKeys(enabled bool) Option {
+	return func(rcm *RedisConfigManager) {
+		rcm.foldCase = enabled
+	}
+}
+
+// foldKey returns key in the form it is stored under.
+func (rcm *RedisConfigManager) foldKey(key string) string {
+	if !rcm.foldCase {
+		return key
+	}
+
+	return strings.ToLower(key)
+}
+
+// foldKeys returns copies of a flattened config and its decoded values with
+// every key lowercased, or the maps themselves when case-insensitive keys
+// are disabled.
+func (rcm *RedisConfigManager) foldKeys(values map[string]string, natives map[string]any) (map[string]string, map[string]any) {
+	if !rcm.foldCase {
+		return values, natives
+	}
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	foldedValues := make(map[string]string, len(values))
+	foldedNatives := make(map[string]any, len(natives))
+	sources := make(map[string]string, len(values))
+	for _, key := range keys {
+		lower := strings.ToLower(key)
+		if source, ok := sources[lower]; ok {
+			rcm.log().Warnf("keys %s and %s differ only in case, keeping %s", source, key, key)
+		}
+		sources[lower] = key
+
+		foldedValues[lower] = values[key]
+		if native, ok := natives[key]; ok {
+			foldedNatives[lower] = native
+		} else {
+			delete(foldedNatives, lower)
+		}
+	}
+
+	return foldedValues, foldedNatives
+}
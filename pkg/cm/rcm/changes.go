@@ -0,0 +1,178 @@
+package rcm
+
+import (
+	"sort"
+	"time"
+)
+
+// ChangeFunc is called for every key whose value differs between two loads.
+// Added keys have an empty oldValue and removed keys an empty newValue.
+type ChangeFunc func(key, oldValue, newValue string)
+
+type change struct {
+	key      string
+	oldValue string
+	newValue string
+}
+
+// OnChange registers a callback that is invoked by LoadConfig for every
+// changed key. Callbacks run after the lock is released, so they may safely
+// call the getters.
+func (rcm *RedisConfigManager) OnChange(callback ChangeFunc) {
+	rcm.mu.Lock()
+	defer rcm.mu.Unlock()
+
+	rcm.onChange = append(rcm.onChange, callback)
+}
+
+// Watch returns a channel that receives a value after every load that
+// changed the config. Signals are coalesced: a consumer that falls behind
+// sees one pending signal rather than one per load, and should re-read the
+// values it needs. The channel is closed by StopLoading.
+func (rcm *RedisConfigManager) Watch() <-chan struct{} {
+	rcm.mu.Lock()
+	defer rcm.mu.Unlock()
+
+	watcher := make(chan struct{}, 1)
+	rcm.watchers = append(rcm.watchers, watcher)
+
+	return watcher
+}
+
+// WatchKey returns a channel that receives the new value of key after every
+// load that changed it, or an empty string when a load removed it. Like
+// Watch, signals are coalesced: a consumer that falls behind finds only the
+// latest value. The channel is closed by StopLoading.
+func (rcm *RedisConfigManager) WatchKey(key string) <-chan string {
+	rcm.mu.Lock()
+	defer rcm.mu.Unlock()
+
+	if rcm.keyWatchers == nil {
+		rcm.keyWatchers = make(map[string][]chan string)
+	}
+	key = rcm.foldKey(key)
+	watcher := make(chan string, 1)
+	rcm.keyWatchers[key] = append(rcm.keyWatchers[key], watcher)
+
+	return watcher
+}
+
+// signalKeyWatchers sends every changed value to the watchers of its key,
+// replacing a value the watcher has not received yet. It must be called with
+// the write lock held, so that it cannot race with closeWatchers.
+func (rcm *RedisConfigManager) signalKeyWatchers(changes []change) {
+	for _, c := range changes {
+		for _, watcher := range rcm.keyWatchers[c.key] {
+			select {
+			case <-watcher:
+			default:
+			}
+			watcher <- c.newValue
+		}
+	}
+}
+
+// signalWatchers must be called with the write lock held, so that it cannot
+// race with closeWatchers.
+func (rcm *RedisConfigManager) signalWatchers() {
+	for _, watcher := range rcm.watchers {
+		select {
+		case watcher <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (rcm *RedisConfigManager) closeWatchers() {
+	rcm.mu.Lock()
+	defer rcm.mu.Unlock()
+
+	for _, watcher := range rcm.watchers {
+		close(watcher)
+	}
+	rcm.watchers = nil
+
+	for _, watchers := range rcm.keyWatchers {
+		for _, watcher := range watchers {
+			close(watcher)
+		}
+	}
+	rcm.keyWatchers = nil
+}
+
+// KeyUpdatedAt returns when the value of key last changed in a load. The
+// boolean is false for keys that are not loaded.
+func (rcm *RedisConfigManager) KeyUpdatedAt(key string) (time.Time, bool) {
+	rcm.mu.RLock()
+	defer rcm.mu.RUnlock()
+
+	updatedAt, ok := rcm.keyUpdates[rcm.foldKey(key)]
+	return updatedAt, ok
+}
+
+// LastChangedKeys returns the sorted keys that were added, removed or
+// modified by the most recent successful load. It is empty when that load
+// changed nothing.
+func (rcm *RedisConfigManager) LastChangedKeys() []string {
+	rcm.mu.RLock()
+	defer rcm.mu.RUnlock()
+
+	return append([]string{}, rcm.lastChanged...)
+}
+
+func changedKeys(changes []change) []string {
+	keys := make([]string, 0, len(changes))
+	for _, c := range changes {
+		keys = append(keys, c.key)
+	}
+
+	return keys
+}
+
+// trackKeyUpdates records the change time of every changed key. The caller
+// must hold the write lock.
+func (rcm *RedisConfigManager) trackKeyUpdates(changes []change, now time.Time) {
+	if rcm.keyUpdates == nil {
+		rcm.keyUpdates = make(map[string]time.Time)
+	}
+
+	for _, c := range changes {
+		if _, ok := rcm.config[c.key]; !ok {
+			delete(rcm.keyUpdates, c.key)
+			continue
+		}
+		rcm.keyUpdates[c.key] = now
+	}
+}
+
+func (rcm *RedisConfigManager) notifyChanges(callbacks []ChangeFunc, changes []change) {
+	for _, c := range changes {
+		for _, callback := range callbacks {
+			callback(c.key, c.oldValue, c.newValue)
+		}
+	}
+}
+
+// diffConfig returns the changes between two config maps sorted by key.
+func diffConfig(oldConfig, newConfig map[string]string) []change {
+	var changes []change
+
+	for key, newValue := range newConfig {
+		oldValue, ok := oldConfig[key]
+		if !ok || oldValue != newValue {
+			changes = append(changes, change{key: key, oldValue: oldValue, newValue: newValue})
+		}
+	}
+
+	for key, oldValue := range oldConfig {
+		if _, ok := newConfig[key]; !ok {
+			changes = append(changes, change{key: key, oldValue: oldValue})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].key < changes[j].key
+	})
+
+	return changes
+}
@@ -0,0 +1,295 @@
+package rcm
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestOnChange(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	serviceName := "test_service"
+	if err := mr.Set(serviceName, `{"a": "1", "b": "2"}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+
+	rcm := &RedisConfigManager{
+		serviceName: serviceName,
+		config:      make(map[string]string),
+		r:           client,
+		ctx:         context.Background(),
+	}
+
+	if err := rcm.LoadConfig(context.Background()); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	var got []change
+	rcm.OnChange(func(key, oldValue, newValue string) {
+		got = append(got, change{key: key, oldValue: oldValue, newValue: newValue})
+
+		// Callbacks run outside the lock, so reading config must not deadlock.
+		_, _ = rcm.GetString(key)
+	})
+
+	if err := mr.Set(serviceName, `{"a": "1", "b": "3", "c": "4"}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+	if err := rcm.LoadConfig(context.Background()); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	expected := []change{
+		{key: "b", oldValue: "2", newValue: "3"},
+		{key: "c", oldValue: "", newValue: "4"},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected changes %v, got %v", expected, got)
+	}
+
+	got = nil
+	if err := rcm.LoadConfig(context.Background()); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if len(got) != 0 {
+		t.Errorf("expected no changes on identical reload, got %v", got)
+	}
+}
+
+func TestDiffConfig(t *testing.T) {
+	oldConfig := map[string]string{"kept": "1", "modified": "2", "removed": "3"}
+	newConfig := map[string]string{"kept": "1", "modified": "20", "added": "4"}
+
+	expected := []change{
+		{key: "added", oldValue: "", newValue: "4"},
+		{key: "modified", oldValue: "2", newValue: "20"},
+		{key: "removed", oldValue: "3", newValue: ""},
+	}
+
+	got := diffConfig(oldConfig, newConfig)
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected changes %v, got %v", expected, got)
+	}
+}
+
+func TestKeyUpdatedAt(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	serviceName := "test_service"
+	if err := mr.Set(serviceName, `{"stable": "1", "moving": "1", "removed": "1"}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+
+	rcm := &RedisConfigManager{
+		serviceName: serviceName,
+		config:      make(map[string]string),
+		r:           client,
+		ctx:         context.Background(),
+	}
+
+	if err := rcm.LoadConfig(context.Background()); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	firstLoad, ok := rcm.KeyUpdatedAt("stable")
+	if !ok || firstLoad.IsZero() {
+		t.Fatal("expected stable to have an update time after the first load")
+	}
+
+	time.Sleep(5 * time.Millisecond)
+
+	if err := mr.Set(serviceName, `{"stable": "1", "moving": "2"}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+	if err := rcm.LoadConfig(context.Background()); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	if updatedAt, _ := rcm.KeyUpdatedAt("stable"); !updatedAt.Equal(firstLoad) {
+		t.Errorf("expected unchanged key to keep %v, got %v", firstLoad, updatedAt)
+	}
+	if updatedAt, _ := rcm.KeyUpdatedAt("moving"); !updatedAt.After(firstLoad) {
+		t.Errorf("expected changed key to be updated after %v, got %v", firstLoad, updatedAt)
+	}
+	if _, ok := rcm.KeyUpdatedAt("removed"); ok {
+		t.Error("expected removed key to have no update time")
+	}
+}
+
+func TestLastChangedKeys(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	serviceName := "test_service"
+	rcm := &RedisConfigManager{
+		serviceName: serviceName,
+		config:      make(map[string]string),
+		r:           client,
+		ctx:         context.Background(),
+	}
+
+	if keys := rcm.LastChangedKeys(); keys == nil || len(keys) != 0 {
+		t.Errorf("expected an empty slice before the first load, got %#v", keys)
+	}
+
+	steps := []struct {
+		name     string
+		document string
+		expected []string
+	}{
+		{"initial load adds every key", `{"kept": "1", "modified": "2", "removed": "3"}`, []string{"kept", "modified", "removed"}},
+		{"add, remove and modify", `{"kept": "1", "modified": "20", "added": "4"}`, []string{"added", "modified", "removed"}},
+		{"nothing changed", `{"kept": "1", "modified": "20", "added": "4"}`, []string{}},
+	}
+
+	for _, step := range steps {
+		if err := mr.Set(serviceName, step.document); err != nil {
+			t.Fatalf("failed to set config in miniredis: %v", err)
+		}
+		if err := rcm.LoadConfig(context.Background()); err != nil {
+			t.Fatalf("LoadConfig failed: %v", err)
+		}
+
+		if keys := rcm.LastChangedKeys(); !reflect.DeepEqual(keys, step.expected) {
+			t.Errorf("%s: expected %v, got %v", step.name, step.expected, keys)
+		}
+	}
+}
+
+func TestWatch(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	serviceName := "test_service"
+	if err := mr.Set(serviceName, `{"a": "1"}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+
+	rcm := NewRedisConfigManagerWithClient(serviceName, client).(*RedisConfigManager)
+	updates := rcm.Watch()
+
+	if err := rcm.LoadConfig(context.Background()); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if err := mr.Set(serviceName, `{"a": "2"}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+	if err := rcm.LoadConfig(context.Background()); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	select {
+	case <-updates:
+	default:
+		t.Fatal("expected a signal after a changing load")
+	}
+	select {
+	case <-updates:
+		t.Fatal("expected signals from both loads to be coalesced")
+	default:
+	}
+
+	if err := rcm.LoadConfig(context.Background()); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	select {
+	case <-updates:
+		t.Fatal("expected no signal for a load without changes")
+	default:
+	}
+
+	rcm.StopLoading()
+	if _, ok := <-updates; ok {
+		t.Error("expected the channel to be closed by StopLoading")
+	}
+}
+
+func TestWatchKey(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	serviceName := "test_service"
+	rcm := NewRedisConfigManagerWithClient(serviceName, client).(*RedisConfigManager)
+	updates := rcm.WatchKey("a")
+
+	load := func(document string) {
+		t.Helper()
+		if err := mr.Set(serviceName, document); err != nil {
+			t.Fatalf("failed to set config in miniredis: %v", err)
+		}
+		if err := rcm.LoadConfig(context.Background()); err != nil {
+			t.Fatalf("LoadConfig failed: %v", err)
+		}
+	}
+
+	load(`{"a": "1", "b": "1"}`)
+	if value := <-updates; value != "1" {
+		t.Errorf("expected 1, got %q", value)
+	}
+
+	load(`{"a": "1", "b": "2"}`)
+	select {
+	case value := <-updates:
+		t.Fatalf("expected no value when another key changed, got %q", value)
+	default:
+	}
+
+	load(`{"a": "2"}`)
+	load(`{"a": "3"}`)
+	if value := <-updates; value != "3" {
+		t.Errorf("expected rapid changes to coalesce to 3, got %q", value)
+	}
+	select {
+	case value := <-updates:
+		t.Fatalf("expected a single coalesced value, got %q", value)
+	default:
+	}
+
+	load(`{}`)
+	if value := <-updates; value != "" {
+		t.Errorf("expected an empty value for a removed key, got %q", value)
+	}
+
+	rcm.StopLoading()
+	if _, ok := <-updates; ok {
+		t.Error("expected the channel to be closed by StopLoading")
+	}
+}
+
+func TestWatchKey_CaseInsensitiveKeys(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	serviceName := "test_service"
+	rcm := NewRedisConfigManagerWithClient(serviceName, client, WithCaseInsensitiveKeys(true)).(*RedisConfigManager)
+	updates := rcm.WatchKey("MaxConns")
+
+	if err := mr.Set(serviceName, `{"MAXCONNS": 10}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+	if err := rcm.LoadConfig(context.Background()); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	select {
+	case value := <-updates:
+		if value != "10" {
+			t.Errorf("expected 10, got %q", value)
+		}
+	default:
+		t.Error("expected a mixed-case watch to fire")
+	}
+	if updatedAt, ok := rcm.KeyUpdatedAt("MaxConns"); !ok || updatedAt.IsZero() {
+		t.Errorf("expected an update time for a mixed-case key, got %v (ok: %v)", updatedAt, ok)
+	}
+}
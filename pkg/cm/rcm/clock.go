@@ -0,0 +1,19 @@
+package rcm
+
+import "time"
+
+// WithClock replaces the clock used for LastUpdated and staleness checks,
+// so tests can advance time without sleeping. The default is time.Now.
+func WithClock(clock func() time.Time) Option {
+	return func(rcm *RedisConfigManager) {
+		rcm.clock = clock
+	}
+}
+
+func (rcm *RedisConfigManager) now() time.Time {
+	if rcm.clock == nil {
+		return time.Now()
+	}
+
+	return rcm.clock()
+}
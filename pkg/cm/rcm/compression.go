@@ -0,0 +1,65 @@
+package rcm
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+)
+
+// Compression is the compression applied to the config document in Redis.
+type Compression int
+
+const (
+	CompressionNone Compression = iota
+	CompressionGzip
+)
+
+// gzipMagic starts every gzip stream.
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// WithCompression makes the manager accept compressed config documents.
+// Documents are recognized by their magic bytes, so uncompressed documents
+// keep loading while a config is being migrated. Set writes documents
+// compressed.
+func WithCompression(compression Compression) Option {
+	return func(rcm *RedisConfigManager) {
+		rcm.compression = compression
+	}
+}
+
+func (rcm *RedisConfigManager) decompress(raw []byte) ([]byte, error) {
+	if rcm.compression != CompressionGzip || !bytes.HasPrefix(raw, gzipMagic) {
+		return raw, nil
+	}
+
+	reader, err := gzip.NewReader(bytes.NewReader(raw))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress config: %w", err)
+	}
+	defer reader.Close()
+
+	decompressed, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress config: %w", err)
+	}
+
+	return decompressed, nil
+}
+
+func (rcm *RedisConfigManager) compress(raw []byte) ([]byte, error) {
+	if rcm.compression != CompressionGzip {
+		return raw, nil
+	}
+
+	var compressed bytes.Buffer
+	writer := gzip.NewWriter(&compressed)
+	if _, err := writer.Write(raw); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	return compressed.Bytes(), nil
+}
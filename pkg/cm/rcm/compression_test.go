@@ -0,0 +1,66 @@
+package rcm
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"testing"
+)
+
+func gzipString(t *testing.T, s string) string {
+	t.Helper()
+
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	if _, err := writer.Write([]byte(s)); err != nil {
+		t.Fatalf("failed to compress: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("failed to compress: %v", err)
+	}
+
+	return buf.String()
+}
+
+func TestWithCompression(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	serviceName := "test_service"
+	rcm := NewRedisConfigManagerWithClient(serviceName, client, WithCompression(CompressionGzip))
+
+	documents := map[string]string{
+		"compressed":   gzipString(t, `{"mode": "compressed"}`),
+		"uncompressed": `{"mode": "uncompressed"}`,
+	}
+	for expected, document := range documents {
+		if err := mr.Set(serviceName, document); err != nil {
+			t.Fatalf("failed to set config in miniredis: %v", err)
+		}
+		if err := rcm.LoadConfig(context.Background()); err != nil {
+			t.Fatalf("LoadConfig failed for %s document: %v", expected, err)
+		}
+		if mode, _ := rcm.GetString("mode"); mode != expected {
+			t.Errorf("expected %s, got %q", expected, mode)
+		}
+	}
+
+	if err := rcm.(*RedisConfigManager).Set(context.Background(), "mode", "written"); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	stored, err := mr.Get(serviceName)
+	if err != nil {
+		t.Fatalf("failed to read config from miniredis: %v", err)
+	}
+	if !bytes.HasPrefix([]byte(stored), gzipMagic) {
+		t.Error("expected Set to store a compressed document")
+	}
+
+	if err := mr.Set(serviceName, "\x1f\x8bnot gzip"); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+	if err := rcm.LoadConfig(context.Background()); err == nil {
+		t.Error("expected an error for a corrupt compressed document")
+	}
+}
@@ -0,0 +1,65 @@
+package rcm
+
+import (
+	"context"
+	"time"
+)
+
+// The context-aware getters read from the local cache, so the context is only
+// checked for cancellation before the lookup.
+
+func (rcm *RedisConfigManager) GetIntContext(ctx context.Context, key string) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	return rcm.GetInt(key)
+}
+
+func (rcm *RedisConfigManager) GetFloatContext(ctx context.Context, key string) (float64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	return rcm.GetFloat(key)
+}
+
+func (rcm *RedisConfigManager) GetStringContext(ctx context.Context, key string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	return rcm.GetString(key)
+}
+
+func (rcm *RedisConfigManager) GetBoolContext(ctx context.Context, key string) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	return rcm.GetBool(key)
+}
+
+func (rcm *RedisConfigManager) GetDurationContext(ctx context.Context, key string) (time.Duration, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	return rcm.GetDuration(key)
+}
+
+func (rcm *RedisConfigManager) GetStringSliceContext(ctx context.Context, key string) ([]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return rcm.GetStringSlice(key)
+}
+
+func (rcm *RedisConfigManager) GetBytesContext(ctx context.Context, key string) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return rcm.GetBytes(key)
+}
@@ -0,0 +1,44 @@
+package rcm
+
+import (
+	"fmt"
+	"strings"
+)
+
+// encryptedPrefix marks config values that must be decrypted on load.
+const encryptedPrefix = "enc:"
+
+// WithDecryptor makes the manager decrypt every loaded value of the form
+// "enc:<ciphertext>". The decryptor receives the ciphertext without the
+// prefix and returns the plaintext that the getters will serve. Values
+// without the prefix are left untouched. A decryption failure fails the
+// load and keeps the previous config.
+//
+// Only leaf values are decrypted: the JSON text kept under the parent key of
+// an object or array still contains the ciphertext.
+func WithDecryptor(decryptor func(ciphertext string) (string, error)) Option {
+	return func(rcm *RedisConfigManager) {
+		rcm.decryptor = decryptor
+	}
+}
+
+func (rcm *RedisConfigManager) decrypt(config map[string]string) error {
+	if rcm.decryptor == nil {
+		return nil
+	}
+
+	for key, value := range config {
+		ciphertext, ok := strings.CutPrefix(value, encryptedPrefix)
+		if !ok {
+			continue
+		}
+
+		plaintext, err := rcm.decryptor(ciphertext)
+		if err != nil {
+			return fmt.Errorf("failed to decrypt key %s: %w", key, err)
+		}
+		config[key] = plaintext
+	}
+
+	return nil
+}
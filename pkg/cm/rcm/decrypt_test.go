@@ -0,0 +1,46 @@
+package rcm
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+)
+
+func TestWithDecryptor(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	serviceName := "test_service"
+	secret := base64.StdEncoding.EncodeToString([]byte("s3cret"))
+	if err := mr.Set(serviceName, `{"password": "enc:`+secret+`", "user": "admin"}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+
+	decode := func(ciphertext string) (string, error) {
+		plaintext, err := base64.StdEncoding.DecodeString(ciphertext)
+		return string(plaintext), err
+	}
+	rcm := NewRedisConfigManagerWithClient(serviceName, client, WithDecryptor(decode))
+
+	if err := rcm.LoadConfig(context.Background()); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	if password, _ := rcm.GetString("password"); password != "s3cret" {
+		t.Errorf("expected decrypted password, got %q", password)
+	}
+	if user, _ := rcm.GetString("user"); user != "admin" {
+		t.Errorf("expected plain value to pass through, got %q", user)
+	}
+
+	if err := mr.Set(serviceName, `{"password": "enc:!!!", "user": "root"}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+	if err := rcm.LoadConfig(context.Background()); err == nil {
+		t.Error("expected LoadConfig to fail when decryption fails")
+	}
+	if user, _ := rcm.GetString("user"); user != "admin" {
+		t.Errorf("expected the previous config to be kept, got user %q", user)
+	}
+}
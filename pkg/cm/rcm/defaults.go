@@ -0,0 +1,32 @@
+package rcm
+
+import (
+	"maps"
+
+	"github.com/zemld/config-manager/pkg/cm/internal/conv"
+)
+
+// SetDefaults registers fallback values for keys missing from the loaded
+// config. The getters resolve a key from the live config first, then from
+// the registered defaults, and only then report cm.ErrKeyNotFound. Values
+// are stored the same way as loaded ones, so nested maps become dotted keys.
+// Calling SetDefaults again adds to the registered defaults, replacing
+// values for keys that were already registered.
+func (rcm *RedisConfigManager) SetDefaults(defaults map[string]any) {
+	rcm.mu.Lock()
+	defer rcm.mu.Unlock()
+
+	merged := make(map[string]string, len(rcm.defaults)+len(defaults))
+	mergedNatives := make(map[string]any, len(rcm.defaultNatives))
+	maps.Copy(merged, rcm.defaults)
+	maps.Copy(mergedNatives, rcm.defaultNatives)
+
+	values, natives := rcm.foldKeys(conv.FlattenNative(defaults))
+	for key, value := range values {
+		merged[key] = value
+		mergedNatives[key] = natives[key]
+	}
+	rcm.defaults = merged
+	rcm.defaultNatives = mergedNatives
+	rcm.publish()
+}
@@ -0,0 +1,24 @@
+package rcm
+
+// WithDefaultsKey makes LoadConfig read a shared defaults document from key
+// and merge the service config over it, so the service wins for every key it
+// sets. Objects are merged recursively. A missing defaults key is skipped.
+// The defaults document sits below any fallback keys and, like them, is not
+// prefixed by WithNamespace.
+func WithDefaultsKey(key string) Option {
+	return func(rcm *RedisConfigManager) {
+		rcm.defaultsKey = key
+	}
+}
+
+// fallbackKeys returns the keys merged below the config key, highest
+// precedence first.
+func (rcm *RedisConfigManager) fallbackKeys() []string {
+	if rcm.defaultsKey == "" {
+		return rcm.fallbacks
+	}
+
+	keys := make([]string, 0, len(rcm.fallbacks)+1)
+	keys = append(keys, rcm.fallbacks...)
+	return append(keys, rcm.defaultsKey)
+}
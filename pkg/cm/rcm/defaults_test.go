@@ -0,0 +1,61 @@
+package rcm
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/zemld/config-manager/pkg/cm"
+)
+
+func TestSetDefaults(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	serviceName := "test_service"
+	if err := mr.Set(serviceName, `{"port": 9090}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+
+	rcm := &RedisConfigManager{
+		serviceName: serviceName,
+		config:      make(map[string]string),
+		r:           client,
+		ctx:         context.Background(),
+	}
+	rcm.SetDefaults(map[string]any{
+		"port":    8080,
+		"timeout": "5s",
+		"db":      map[string]any{"host": "localhost"},
+	})
+
+	if err := rcm.LoadConfig(context.Background()); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	if port, err := rcm.GetInt("port"); err != nil || port != 9090 {
+		t.Errorf("expected live value 9090 to win over the default, got %d (err: %v)", port, err)
+	}
+	if timeout, err := rcm.GetDuration("timeout"); err != nil || timeout != 5*time.Second {
+		t.Errorf("expected default 5s, got %v (err: %v)", timeout, err)
+	}
+	if host, err := rcm.GetString("db.host"); err != nil || host != "localhost" {
+		t.Errorf("expected nested default localhost, got %q (err: %v)", host, err)
+	}
+	if _, err := rcm.GetString("missing"); !errors.Is(err, cm.ErrKeyNotFound) {
+		t.Errorf("expected ErrKeyNotFound without a default, got %v", err)
+	}
+
+	if err := mr.Set(serviceName, `{}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+	if err := rcm.LoadConfig(context.Background()); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	if port, err := rcm.GetInt("port"); err != nil || port != 8080 {
+		t.Errorf("expected default 8080 once the live key is removed, got %d (err: %v)", port, err)
+	}
+}
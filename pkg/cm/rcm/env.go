@@ -0,0 +1,66 @@
+package rcm
+
+import (
+	"errors"
+	"os"
+	"time"
+
+	"github.com/zemld/config-manager/pkg/cm"
+	"github.com/zemld/config-manager/pkg/cm/internal/conv"
+)
+
+// The OrEnv getters fall back to envVar and then defaultValue, as described
+// in the cm package documentation. A stale value is still preferred over the
+// environment.
+
+func (rcm *RedisConfigManager) GetStringOrEnv(key, envVar, defaultValue string) string {
+	value, err := rcm.GetString(key)
+	if err == nil || errors.Is(err, cm.ErrStale) {
+		return value
+	}
+
+	if env := os.Getenv(envVar); env != "" {
+		return env
+	}
+
+	return defaultValue
+}
+
+func (rcm *RedisConfigManager) GetIntOrEnv(key, envVar string, defaultValue int) int {
+	value, err := rcm.GetInt(key)
+	if err == nil || errors.Is(err, cm.ErrStale) {
+		return value
+	}
+
+	if env, err := conv.Int(os.Getenv(envVar)); err == nil {
+		return env
+	}
+
+	return defaultValue
+}
+
+func (rcm *RedisConfigManager) GetBoolOrEnv(key, envVar string, defaultValue bool) bool {
+	value, err := rcm.GetBool(key)
+	if err == nil || errors.Is(err, cm.ErrStale) {
+		return value
+	}
+
+	if env, err := conv.Bool(os.Getenv(envVar)); err == nil {
+		return env
+	}
+
+	return defaultValue
+}
+
+func (rcm *RedisConfigManager) GetDurationOrEnv(key, envVar string, defaultValue time.Duration) time.Duration {
+	value, err := rcm.GetDuration(key)
+	if err == nil || errors.Is(err, cm.ErrStale) {
+		return value
+	}
+
+	if env, err := conv.Duration(os.Getenv(envVar)); err == nil {
+		return env
+	}
+
+	return defaultValue
+}
@@ -0,0 +1,49 @@
+package rcm
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGetOrEnv(t *testing.T) {
+	rcm := &RedisConfigManager{
+		serviceName: "test_service",
+		config:      map[string]string{"host": "from-config", "port": "8080", "bad_port": "eighty"},
+	}
+
+	t.Setenv("TEST_HOST", "from-env")
+	t.Setenv("TEST_PORT", "9090")
+	t.Setenv("TEST_BAD_PORT", "ninety")
+	t.Setenv("TEST_DEBUG", "yes")
+	t.Setenv("TEST_EMPTY", "")
+
+	if value := rcm.GetStringOrEnv("host", "TEST_HOST", "default"); value != "from-config" {
+		t.Errorf("expected the config to win, got %q", value)
+	}
+	if value := rcm.GetStringOrEnv("missing", "TEST_HOST", "default"); value != "from-env" {
+		t.Errorf("expected the environment variable, got %q", value)
+	}
+	if value := rcm.GetStringOrEnv("missing", "TEST_EMPTY", "default"); value != "default" {
+		t.Errorf("expected the default for an empty variable, got %q", value)
+	}
+	if value := rcm.GetStringOrEnv("missing", "TEST_UNSET", "default"); value != "default" {
+		t.Errorf("expected the default for an unset variable, got %q", value)
+	}
+
+	if value := rcm.GetIntOrEnv("port", "TEST_PORT", 1); value != 8080 {
+		t.Errorf("expected 8080 from the config, got %d", value)
+	}
+	if value := rcm.GetIntOrEnv("bad_port", "TEST_PORT", 1); value != 9090 {
+		t.Errorf("expected 9090 from the environment for an unparsable value, got %d", value)
+	}
+	if value := rcm.GetIntOrEnv("missing", "TEST_BAD_PORT", 1); value != 1 {
+		t.Errorf("expected the default for an unparsable variable, got %d", value)
+	}
+
+	if value := rcm.GetBoolOrEnv("missing", "TEST_DEBUG", false); !value {
+		t.Error("expected true from the environment")
+	}
+	if value := rcm.GetDurationOrEnv("missing", "TEST_UNSET", time.Second); value != time.Second {
+		t.Errorf("expected the default duration, got %v", value)
+	}
+}
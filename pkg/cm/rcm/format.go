@@ -0,0 +1,108 @@
+package rcm
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/zemld/config-manager/pkg/cm/internal/conv"
+	"gopkg.in/yaml.v3"
+)
+
+// Format is the encoding of the config document stored in Redis.
+type Format int
+
+const (
+	FormatJSON Format = iota
+	FormatYAML
+)
+
+// WithFormat sets the encoding of the config document. The default is JSON.
+func WithFormat(format Format) Option {
+	return func(rcm *RedisConfigManager) {
+		rcm.format = format
+	}
+}
+
+// Decoder turns a raw config document into a map, for formats the manager
+// does not support itself such as encrypted envelopes. Nested objects must be
+// map[string]any and lists []any so that they are flattened into dotted
+// keys.
+type Decoder interface {
+	Decode(raw []byte) (map[string]any, error)
+}
+
+// JSONDecoder decodes JSON documents, keeping numbers as json.Number.
+type JSONDecoder struct{}
+
+func (JSONDecoder) Decode(raw []byte) (map[string]any, error) {
+	document := make(map[string]any)
+	if err := conv.UnmarshalJSON(raw, &document); err != nil {
+		return nil, err
+	}
+
+	return document, nil
+}
+
+// YAMLDecoder decodes YAML documents.
+type YAMLDecoder struct{}
+
+func (YAMLDecoder) Decode(raw []byte) (map[string]any, error) {
+	document := make(map[string]any)
+	if err := yaml.Unmarshal(raw, &document); err != nil {
+		return nil, err
+	}
+
+	return document, nil
+}
+
+// WithDecoder makes the manager decode config documents with decoder
+// instead of the one chosen by WithFormat. Decompression still runs first.
+// Set needs to encode documents, so it fails on a manager with a custom
+// decoder.
+func WithDecoder(decoder Decoder) Option {
+	return func(rcm *RedisConfigManager) {
+		rcm.decoder = decoder
+	}
+}
+
+func (rcm *RedisConfigManager) decode(raw []byte) (map[string]any, error) {
+	raw, err := rcm.decompress(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	return rcm.documentDecoder().Decode(raw)
+}
+
+func (rcm *RedisConfigManager) documentDecoder() Decoder {
+	if rcm.decoder != nil {
+		return rcm.decoder
+	}
+
+	switch rcm.format {
+	case FormatYAML:
+		return YAMLDecoder{}
+	default:
+		return JSONDecoder{}
+	}
+}
+
+func (rcm *RedisConfigManager) encode(document map[string]any) ([]byte, error) {
+	if rcm.decoder != nil {
+		return nil, errors.New("cannot encode config with a custom decoder")
+	}
+
+	var raw []byte
+	var err error
+	switch rcm.format {
+	case FormatYAML:
+		raw, err = yaml.Marshal(document)
+	default:
+		raw, err = json.Marshal(document)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return rcm.compress(raw)
+}
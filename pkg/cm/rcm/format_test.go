@@ -0,0 +1,150 @@
+package rcm
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWithFormat_YAML(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	serviceName := "test_service"
+	document := "int_key: 42\nduration_key: 5s\ndb:\n  host: x\n  port: 5432\nlist:\n  - a\n  - b\n"
+	if err := mr.Set(serviceName, document); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+
+	rcm := &RedisConfigManager{
+		serviceName: serviceName,
+		config:      make(map[string]string),
+		r:           client,
+		ctx:         context.Background(),
+	}
+	WithFormat(FormatYAML)(rcm)
+
+	if err := rcm.LoadConfig(context.Background()); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	if value, err := rcm.GetInt("int_key"); err != nil || value != 42 {
+		t.Errorf("expected 42, got %d (%v)", value, err)
+	}
+	if value, err := rcm.GetDuration("duration_key"); err != nil || value != 5*time.Second {
+		t.Errorf("expected 5s, got %v (%v)", value, err)
+	}
+	if value, err := rcm.GetInt("db.port"); err != nil || value != 5432 {
+		t.Errorf("expected db.port 5432, got %d (%v)", value, err)
+	}
+	if value, err := rcm.GetString("list.1"); err != nil || value != "b" {
+		t.Errorf("expected list.1 'b', got '%s' (%v)", value, err)
+	}
+
+	if err := rcm.Set(context.Background(), "int_key", 43); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if err := rcm.LoadConfig(context.Background()); err != nil {
+		t.Fatalf("LoadConfig failed after Set: %v", err)
+	}
+	if value, err := rcm.GetInt("int_key"); err != nil || value != 43 {
+		t.Errorf("expected 43 after Set, got %d (%v)", value, err)
+	}
+}
+
+func TestWithFormat_DefaultsToJSON(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	serviceName := "test_service"
+	if err := mr.Set(serviceName, "int_key: 42\n"); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+
+	rcm := &RedisConfigManager{
+		serviceName: serviceName,
+		config:      make(map[string]string),
+		r:           client,
+		ctx:         context.Background(),
+	}
+
+	if err := rcm.LoadConfig(context.Background()); err == nil {
+		t.Error("expected YAML document to be rejected without WithFormat")
+	}
+}
+
+// lineDecoder reads "key=value" lines, standing in for a proprietary format.
+type lineDecoder struct{}
+
+func (lineDecoder) Decode(raw []byte) (map[string]any, error) {
+	document := make(map[string]any)
+	for _, line := range strings.Split(strings.TrimSpace(string(raw)), "\n") {
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("malformed line %q", line)
+		}
+		document[key] = value
+	}
+
+	return document, nil
+}
+
+func TestWithDecoder(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	serviceName := "test_service"
+	if err := mr.Set(serviceName, "int_key=42\nname=svc\n"); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+
+	rcm := NewRedisConfigManagerWithClient(serviceName, client, WithDecoder(lineDecoder{})).(*RedisConfigManager)
+	if err := rcm.LoadConfig(context.Background()); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	if value, err := rcm.GetInt("int_key"); err != nil || value != 42 {
+		t.Errorf("expected 42, got %d (%v)", value, err)
+	}
+	if value, err := rcm.GetString("name"); err != nil || value != "svc" {
+		t.Errorf("expected 'svc', got '%s' (%v)", value, err)
+	}
+	if err := rcm.Set(context.Background(), "int_key", 43); err == nil {
+		t.Error("expected Set to fail with a custom decoder")
+	}
+
+	if err := mr.Set(serviceName, "broken"); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+	if err := rcm.LoadConfig(context.Background()); err == nil {
+		t.Error("expected the decoder error to fail the load")
+	}
+}
+
+func TestBuiltInDecoders(t *testing.T) {
+	decoders := map[string]struct {
+		decoder Decoder
+		raw     string
+	}{
+		"json": {JSONDecoder{}, `{"db": {"port": 5432}}`},
+		"yaml": {YAMLDecoder{}, "db:\n  port: 5432\n"},
+	}
+	for name, tt := range decoders {
+		document, err := tt.decoder.Decode([]byte(tt.raw))
+		if err != nil {
+			t.Fatalf("%s: Decode failed: %v", name, err)
+		}
+		db, ok := document["db"].(map[string]any)
+		if !ok {
+			t.Fatalf("%s: expected a nested map, got %T", name, document["db"])
+		}
+		if port := fmt.Sprint(db["port"]); port != "5432" {
+			t.Errorf("%s: expected port 5432, got %s", name, port)
+		}
+	}
+}
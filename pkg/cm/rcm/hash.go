@@ -0,0 +1,54 @@
+package rcm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/zemld/config-manager/pkg/cm"
+)
+
+// NewRedisHashConfigManager creates a manager that reads its config from a
+// Redis hash (HSET serviceName field value ...) instead of a JSON document.
+// Each hash field becomes a config key with the field value as-is.
+func NewRedisHashConfigManager(serviceName string, redisOptions *redis.Options, opts ...Option) cm.ConfigManager {
+	rcm := NewRedisConfigManager(serviceName, redisOptions, opts...).(*RedisConfigManager)
+	rcm.hash = true
+
+	return rcm
+}
+
+func (rcm *RedisConfigManager) fetchHash(ctx context.Context, client redis.UniversalClient, key string) (map[string]any, error) {
+	fields, err := client.HGetAll(ctx, key).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get config: %w", err)
+	}
+
+	// Redis removes empty hashes, so no fields means the key does not exist.
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("failed to get config: %w", redis.Nil)
+	}
+
+	document := make(map[string]any, len(fields))
+	for field, value := range fields {
+		document[field] = value
+	}
+
+	return document, nil
+}
+
+// readHash is the hash counterpart of readDocument: it returns the fields
+// stored under key, or an empty document if the key does not exist yet.
+func (rcm *RedisConfigManager) readHash(ctx context.Context, tx *redis.Tx, key string) (map[string]any, error) {
+	fields, err := tx.HGetAll(ctx, key).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get config: %w", err)
+	}
+
+	document := make(map[string]any, len(fields))
+	for field, value := range fields {
+		document[field] = value
+	}
+
+	return document, nil
+}
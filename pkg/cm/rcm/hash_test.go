@@ -0,0 +1,279 @@
+package rcm
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/zemld/config-manager/pkg/cm"
+)
+
+func TestNewRedisHashConfigManager(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	serviceName := "test_service"
+	mr.HSet(serviceName, "int_key", "42", "string_key", "test_value", "duration_key", "5s")
+
+	rcm := NewRedisHashConfigManager(serviceName, &redis.Options{
+		Addr: mr.Addr(),
+	})
+	defer rcm.StopLoading()
+
+	if err := rcm.LoadConfig(context.Background()); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	if value, err := rcm.GetInt("int_key"); err != nil || value != 42 {
+		t.Errorf("expected 42, got %d (%v)", value, err)
+	}
+	if value, err := rcm.GetString("string_key"); err != nil || value != "test_value" {
+		t.Errorf("expected 'test_value', got '%s' (%v)", value, err)
+	}
+	if value, err := rcm.GetDuration("duration_key"); err != nil || value != 5*time.Second {
+		t.Errorf("expected 5s, got %v (%v)", value, err)
+	}
+
+	if err := rcm.(*RedisConfigManager).Set(context.Background(), "int_key", 43); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if stored := mr.HGet(serviceName, "int_key"); stored != "43" {
+		t.Errorf("expected hash field to be '43', got '%s'", stored)
+	}
+	if value, err := rcm.GetInt("int_key"); err != nil || value != 43 {
+		t.Errorf("expected 43 after Set, got %d (%v)", value, err)
+	}
+}
+
+func TestSet_HashRejectedByValidator(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	serviceName := "test_service"
+	mr.HSet(serviceName, "port", "8080")
+
+	rcm := NewRedisConfigManagerWithClient(serviceName, client, WithVersionKey("test_service:version")).(*RedisConfigManager)
+	rcm.hash = true
+	rcm.SetValidator(func(config map[string]string) error {
+		if config["port"] == "0" {
+			return errors.New("port must not be 0")
+		}
+		return nil
+	})
+	if err := rcm.LoadConfig(context.Background()); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	if err := rcm.Set(context.Background(), "port", 0); err == nil {
+		t.Fatal("expected Set to fail for a config rejected by the validator")
+	}
+	if stored := mr.HGet(serviceName, "port"); stored != "8080" {
+		t.Errorf("expected the rejected field not to be written, got '%s'", stored)
+	}
+	if mr.Exists("test_service:version") {
+		t.Error("expected the version not to be bumped for a rejected Set")
+	}
+	if value, err := rcm.GetInt("port"); err != nil || value != 8080 {
+		t.Errorf("expected the previous config to be kept, got %d (%v)", value, err)
+	}
+}
+
+func TestNewRedisHashConfigManager_MissingKey(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	rcm := NewRedisHashConfigManager("test_service", &redis.Options{
+		Addr: mr.Addr(),
+	})
+	defer rcm.StopLoading()
+
+	if err := rcm.LoadConfig(context.Background()); !errors.Is(err, redis.Nil) {
+		t.Errorf("expected error wrapping redis.Nil, got %v", err)
+	}
+}
+
+func TestGetStringLazy(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	serviceName := "test_service"
+	mr.HSet(serviceName, "rare_key", "rare_value")
+
+	rcm := NewRedisHashConfigManager(serviceName, &redis.Options{
+		Addr: mr.Addr(),
+	}).(*RedisConfigManager)
+	defer rcm.StopLoading()
+	ctx := context.Background()
+
+	if value, err := rcm.GetStringLazy(ctx, "rare_key"); err != nil || value != "rare_value" {
+		t.Errorf("expected 'rare_value', got '%s' (%v)", value, err)
+	}
+
+	mr.HSet(serviceName, "rare_key", "changed")
+	if value, _ := rcm.GetStringLazy(ctx, "rare_key"); value != "rare_value" {
+		t.Errorf("expected the fetched value to be cached, got '%s'", value)
+	}
+
+	if _, err := rcm.GetStringLazy(ctx, "missing_key"); !errors.Is(err, cm.ErrKeyNotFound) {
+		t.Errorf("expected ErrKeyNotFound, got %v", err)
+	}
+
+	if err := rcm.LoadConfig(ctx); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if value, _ := rcm.GetStringLazy(ctx, "rare_key"); value != "changed" {
+		t.Errorf("expected a load to replace the cached value, got '%s'", value)
+	}
+}
+
+func TestGetStringLazy_CaseInsensitiveKeys(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	serviceName := "test_service"
+	mr.HSet(serviceName, "loaded", "1")
+
+	rcm := NewRedisConfigManagerWithClient(serviceName, client, WithCaseInsensitiveKeys(true)).(*RedisConfigManager)
+	rcm.hash = true
+	if err := rcm.LoadConfig(context.Background()); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	ctx := context.Background()
+
+	mr.HSet(serviceName, "Rare_Key", "rare_value")
+	if value, err := rcm.GetStringLazy(ctx, "Rare_Key"); err != nil || value != "rare_value" {
+		t.Fatalf("expected 'rare_value', got '%s' (%v)", value, err)
+	}
+
+	mr.HDel(serviceName, "Rare_Key")
+	if value, err := rcm.GetStringLazy(ctx, "RARE_KEY"); err != nil || value != "rare_value" {
+		t.Errorf("expected the cached value under any case, got '%s' (%v)", value, err)
+	}
+}
+
+func TestGetStringLazy_DecryptsAndTransforms(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	serviceName := "test_service"
+	mr.HSet(serviceName, "loaded", "1")
+
+	reverse := func(ciphertext string) (string, error) {
+		runes := []rune(ciphertext)
+		slices.Reverse(runes)
+		return string(runes), nil
+	}
+	rcm := NewRedisConfigManagerWithClient(serviceName, client,
+		WithDecryptor(reverse), WithKeyTransform(strings.ToLower),
+	).(*RedisConfigManager)
+	rcm.hash = true
+	if err := rcm.LoadConfig(context.Background()); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	ctx := context.Background()
+
+	mr.HSet(serviceName, "API_Token", "enc:terces")
+	if value, err := rcm.GetStringLazy(ctx, "API_Token"); err != nil || value != "secret" {
+		t.Fatalf("expected the fetched value to be decrypted, got '%s' (%v)", value, err)
+	}
+
+	mr.HDel(serviceName, "API_Token")
+	if value, err := rcm.GetStringLazy(ctx, "api_token"); err != nil || value != "secret" {
+		t.Errorf("expected the value cached under the transformed key, got '%s' (%v)", value, err)
+	}
+}
+
+func TestGetStringLazy_LoadDuringFetch(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	serviceName := "test_service"
+	mr.HSet(serviceName, "loaded", "1")
+
+	var rcm *RedisConfigManager
+	reload := func(ciphertext string) (string, error) {
+		// A load that runs between the HGET and the cache write publishes
+		// a config without the field.
+		mr.HDel(serviceName, "late")
+		if err := rcm.LoadConfig(context.Background()); err != nil {
+			return "", err
+		}
+		return ciphertext, nil
+	}
+	rcm = NewRedisConfigManagerWithClient(serviceName, client, WithDecryptor(reload)).(*RedisConfigManager)
+	rcm.hash = true
+	if err := rcm.LoadConfig(context.Background()); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	ctx := context.Background()
+
+	mr.HSet(serviceName, "late", "enc:value")
+	if value, err := rcm.GetStringLazy(ctx, "late"); err != nil || value != "value" {
+		t.Fatalf("expected the fetched value, got '%s' (%v)", value, err)
+	}
+	if _, err := rcm.GetStringLazy(ctx, "late"); !errors.Is(err, cm.ErrKeyNotFound) {
+		t.Errorf("expected a value fetched across a load not to be cached, got %v", err)
+	}
+}
+
+func TestGetStringLazy_MaxAge(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	serviceName := "test_service"
+	mr.HSet(serviceName, "loaded", "1")
+
+	now := time.Now()
+	rcm := NewRedisConfigManagerWithClient(serviceName, client,
+		WithMaxAge(time.Minute), WithClock(func() time.Time { return now }),
+	).(*RedisConfigManager)
+	rcm.hash = true
+	if err := rcm.LoadConfig(context.Background()); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	now = now.Add(2 * time.Minute)
+	mr.HSet(serviceName, "late", "value")
+	for range 2 {
+		value, err := rcm.GetStringLazy(context.Background(), "late")
+		if !errors.Is(err, cm.ErrStale) || value != "value" {
+			t.Errorf("expected the value with ErrStale, got '%s' (%v)", value, err)
+		}
+	}
+}
+
+func TestGetStringLazy_Document(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	if err := mr.Set("myservice", `{"key": "value"}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+
+	rcm := NewRedisConfigManagerWithClient("myservice", client).(*RedisConfigManager)
+	ctx := context.Background()
+
+	if _, err := rcm.GetStringLazy(ctx, "key"); !errors.Is(err, cm.ErrKeyNotFound) {
+		t.Errorf("expected a document manager not to fetch single keys, got %v", err)
+	}
+
+	if err := rcm.LoadConfig(ctx); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if value, err := rcm.GetStringLazy(ctx, "key"); err != nil || value != "value" {
+		t.Errorf("expected 'value', got '%s' (%v)", value, err)
+	}
+}
@@ -0,0 +1,25 @@
+package rcm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// HealthCheck reports whether the manager is ready to serve config, which
+// suits readiness probes. It pings Redis and fails when no config has been
+// loaded yet or when the most recent load failed.
+func (rcm *RedisConfigManager) HealthCheck(ctx context.Context) error {
+	if err := rcm.client().Ping(ctx).Err(); err != nil {
+		return fmt.Errorf("failed to ping redis: %w", err)
+	}
+
+	if !rcm.IsLoaded() {
+		return errors.New("config has not been loaded")
+	}
+	if err := rcm.LastError(); err != nil {
+		return fmt.Errorf("last config load failed: %w", err)
+	}
+
+	return nil
+}
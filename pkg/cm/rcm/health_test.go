@@ -0,0 +1,53 @@
+package rcm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/zemld/config-manager/pkg/cm"
+)
+
+func TestHealthCheck(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	manager := NewRedisConfigManagerWithClient("myservice", client)
+	checker := manager.(cm.HealthChecker)
+	ctx := context.Background()
+
+	if err := checker.HealthCheck(ctx); err == nil {
+		t.Error("expected an error before the first load")
+	}
+
+	if err := mr.Set("myservice", `{"key": "value"}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+	if err := manager.LoadConfig(ctx); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if err := checker.HealthCheck(ctx); err != nil {
+		t.Errorf("expected a healthy manager, got %v", err)
+	}
+
+	if err := mr.Set("myservice", `{invalid`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+	if err := manager.LoadConfig(ctx); err == nil {
+		t.Fatal("expected LoadConfig to fail on invalid JSON")
+	}
+	if err := checker.HealthCheck(ctx); err == nil {
+		t.Error("expected an error after a failed load")
+	}
+
+	if err := mr.Set("myservice", `{"key": "value"}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+	if err := manager.LoadConfig(ctx); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	mr.Close()
+	if err := checker.HealthCheck(ctx); err == nil {
+		t.Error("expected an error when redis is unreachable")
+	}
+}
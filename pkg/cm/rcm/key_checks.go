@@ -0,0 +1,50 @@
+package rcm
+
+import "fmt"
+
+// WithKeyCheck makes every load check the value of key with check, for
+// example that it parses as the type the service expects. A value that
+// fails is left out of the loaded config and its error is kept for KeyError,
+// while the rest of the config loads normally, so one malformed value cannot
+// take the whole config down. The option can be given once per key.
+func WithKeyCheck(key string, check func(value string) error) Option {
+	return func(rcm *RedisConfigManager) {
+		if rcm.keyChecks == nil {
+			rcm.keyChecks = make(map[string]func(string) error)
+		}
+		rcm.keyChecks[key] = check
+	}
+}
+
+// KeyError returns the error that kept key out of the most recent load, or
+// nil if the key loaded fine or has no check.
+func (rcm *RedisConfigManager) KeyError(key string) error {
+	rcm.mu.RLock()
+	defer rcm.mu.RUnlock()
+
+	return rcm.keyErrors[rcm.foldKey(key)]
+}
+
+// checkKeys runs the key checks on config, removes the keys that fail and
+// returns their errors.
+func (rcm *RedisConfigManager) checkKeys(config map[string]string) map[string]error {
+	var keyErrors map[string]error
+	for key, check := range rcm.keyChecks {
+		key = rcm.foldKey(key)
+		value, ok := config[key]
+		if !ok {
+			continue
+		}
+
+		if err := check(value); err != nil {
+			if keyErrors == nil {
+				keyErrors = make(map[string]error)
+			}
+			keyErrors[key] = fmt.Errorf("key %s failed its check: %w", key, err)
+			delete(config, key)
+			rcm.log().Warnf("dropping key %s from config %s: %v", key, rcm.configKey(), err)
+		}
+	}
+
+	return keyErrors
+}
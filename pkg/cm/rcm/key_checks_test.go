@@ -0,0 +1,66 @@
+package rcm
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/zemld/config-manager/pkg/cm"
+)
+
+func TestWithKeyCheck(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	if err := mr.Set("myservice", `{"port": "80x", "workers": 4, "name": "svc"}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+
+	isInt := func(value string) error {
+		_, err := strconv.Atoi(value)
+		return err
+	}
+	rcm := NewRedisConfigManagerWithClient("myservice", client,
+		WithKeyCheck("port", isInt),
+		WithKeyCheck("workers", isInt),
+		WithKeyCheck("missing", isInt),
+	).(*RedisConfigManager)
+
+	if err := rcm.LoadConfig(context.Background()); err != nil {
+		t.Fatalf("expected a malformed key not to fail the load, got %v", err)
+	}
+
+	var numErr *strconv.NumError
+	if err := rcm.KeyError("port"); !errors.As(err, &numErr) {
+		t.Errorf("expected the parse error for port, got %v", err)
+	}
+	if _, err := rcm.GetInt("port"); !errors.Is(err, cm.ErrKeyNotFound) {
+		t.Errorf("expected the malformed key to be left out, got %v", err)
+	}
+	if workers, err := rcm.GetInt("workers"); err != nil || workers != 4 {
+		t.Errorf("expected 4, got %d (err: %v)", workers, err)
+	}
+	if name, _ := rcm.GetString("name"); name != "svc" {
+		t.Errorf("expected unchecked keys to load, got %q", name)
+	}
+	for _, key := range []string{"workers", "missing", "name"} {
+		if err := rcm.KeyError(key); err != nil {
+			t.Errorf("expected no error for %s, got %v", key, err)
+		}
+	}
+
+	if err := mr.Set("myservice", `{"port": 8080, "workers": 4}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+	if err := rcm.LoadConfig(context.Background()); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if err := rcm.KeyError("port"); err != nil {
+		t.Errorf("expected a fixed value to clear the error, got %v", err)
+	}
+	if port, err := rcm.GetInt("port"); err != nil || port != 8080 {
+		t.Errorf("expected 8080, got %d (err: %v)", port, err)
+	}
+}
@@ -0,0 +1,65 @@
+package rcm
+
+import "fmt"
+
+// WithKeyTransform rewrites every key of a loaded document before it is
+// flattened, so that getters can use one canonical form whatever convention
+// the producer used, for example lower snake_case. Nested keys are rewritten
+// segment by segment and the dots joining them are added afterwards. The
+// transform must be deterministic and must not map two keys of the same
+// object to the same name; such a collision fails the load. The default
+// leaves keys unchanged.
+func WithKeyTransform(transform func(key string) string) Option {
+	return func(rcm *RedisConfigManager) {
+		rcm.keyTransform = transform
+	}
+}
+
+// transformKeys returns a copy of document with every object key rewritten.
+// The document itself is not modified.
+func (rcm *RedisConfigManager) transformKeys(document map[string]any) (map[string]any, error) {
+	if rcm.keyTransform == nil {
+		return document, nil
+	}
+
+	transformed, err := transformValue(rcm.keyTransform, "", document)
+	if err != nil {
+		return nil, err
+	}
+
+	return transformed.(map[string]any), nil
+}
+
+func transformValue(transform func(string) string, path string, value any) (any, error) {
+	switch nested := value.(type) {
+	case map[string]any:
+		result := make(map[string]any, len(nested))
+		sources := make(map[string]string, len(nested))
+		for key, nestedValue := range nested {
+			newKey := transform(key)
+			if source, ok := sources[newKey]; ok {
+				return nil, fmt.Errorf("key transform maps both %s and %s to %s", path+source, path+key, path+newKey)
+			}
+			sources[newKey] = key
+
+			transformed, err := transformValue(transform, path+newKey+".", nestedValue)
+			if err != nil {
+				return nil, err
+			}
+			result[newKey] = transformed
+		}
+		return result, nil
+	case []any:
+		result := make([]any, len(nested))
+		for i, item := range nested {
+			transformed, err := transformValue(transform, fmt.Sprintf("%s%d.", path, i), item)
+			if err != nil {
+				return nil, err
+			}
+			result[i] = transformed
+		}
+		return result, nil
+	default:
+		return value, nil
+	}
+}
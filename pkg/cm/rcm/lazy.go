@@ -0,0 +1,73 @@
+package rcm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/zemld/config-manager/pkg/cm"
+)
+
+// GetStringLazy works like GetString, but a manager backed by a hash fetches
+// a key missing from the loaded config, such as a field written since the
+// last load, with a single HGET instead of waiting for the next reload.
+// LoadConfig still reads the whole hash. The key is fetched as given, and the
+// value goes through the same key transform, case folding and decryption as
+// loaded values before it is cached under the resulting key. Hash fields are
+// strings, so they always pass WithStrictTypes. Fetched values are kept until
+// the next load replaces the config; a value fetched while a load was
+// swapping the config in is returned but not cached. Managers backed by a
+// document cannot fetch a single key and serve only the loaded config.
+func (rcm *RedisConfigManager) GetStringLazy(ctx context.Context, key string) (string, error) {
+	loaded := rcm.current.Load()
+	value, err := rcm.GetString(key)
+	if !rcm.hash || !errors.Is(err, cm.ErrKeyNotFound) {
+		return value, err
+	}
+
+	lazyKey := rcm.lazyKey(key)
+	rcm.mu.RLock()
+	value, ok := rcm.lazy[lazyKey]
+	rcm.mu.RUnlock()
+	if ok {
+		return value, rcm.checkAge(nil)
+	}
+
+	raw, err := rcm.client().HGet(ctx, rcm.configKey(), key).Result()
+	if errors.Is(err, redis.Nil) {
+		return "", fmt.Errorf("%w: %s", cm.ErrKeyNotFound, key)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get %s: %w", key, err)
+	}
+
+	values := map[string]string{lazyKey: raw}
+	if err := rcm.decrypt(values); err != nil {
+		return "", err
+	}
+	value = values[lazyKey]
+
+	// A load that published a new view since the lookup above may have read
+	// a newer hash, so the fetched value must not outlive it in the cache.
+	rcm.mu.Lock()
+	if rcm.current.Load() == loaded {
+		if rcm.lazy == nil {
+			rcm.lazy = make(map[string]string)
+		}
+		rcm.lazy[lazyKey] = value
+	}
+	rcm.mu.Unlock()
+
+	return value, rcm.checkAge(nil)
+}
+
+// lazyKey returns the key a hash field is loaded as, after the key
+// transform and case folding.
+func (rcm *RedisConfigManager) lazyKey(field string) string {
+	if rcm.keyTransform != nil {
+		field = rcm.keyTransform(field)
+	}
+
+	return rcm.foldKey(field)
+}
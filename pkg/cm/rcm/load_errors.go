@@ -0,0 +1,116 @@
+package rcm
+
+import (
+	"fmt"
+	"runtime/debug"
+	"time"
+)
+
+// OnLoadError registers a callback that is invoked whenever a background
+// reload started by StartLoading or StartWatching fails. Errors caused by
+// StopLoading cancelling an in-flight reload are not reported.
+func (rcm *RedisConfigManager) OnLoadError(callback func(error)) {
+	rcm.mu.Lock()
+	defer rcm.mu.Unlock()
+
+	rcm.onLoadError = append(rcm.onLoadError, callback)
+}
+
+// LastError returns the error of the most recent LoadConfig, or nil if it
+// succeeded.
+func (rcm *RedisConfigManager) LastError() error {
+	rcm.mu.RLock()
+	defer rcm.mu.RUnlock()
+
+	return rcm.lastErr
+}
+
+// Degraded reports whether the most recent LoadConfig failed. While degraded,
+// the getters keep serving the last successfully loaded config.
+func (rcm *RedisConfigManager) Degraded() bool {
+	rcm.mu.RLock()
+	defer rcm.mu.RUnlock()
+
+	return rcm.lastErr != nil
+}
+
+// DegradedSince returns when the current run of failed loads started, or the
+// zero time when the most recent LoadConfig succeeded.
+func (rcm *RedisConfigManager) DegradedSince() time.Time {
+	rcm.mu.RLock()
+	defer rcm.mu.RUnlock()
+
+	return rcm.degradedSince
+}
+
+// DegradedDuration returns the total time the manager has spent degraded,
+// including the current outage if there is one.
+func (rcm *RedisConfigManager) DegradedDuration() time.Duration {
+	rcm.mu.RLock()
+	defer rcm.mu.RUnlock()
+
+	total := rcm.degradedTotal
+	if !rcm.degradedSince.IsZero() {
+		total += rcm.now().Sub(rcm.degradedSince)
+	}
+
+	return total
+}
+
+// setLastError records the result of a load, counts it and starts or ends
+// the degraded timer. The caller must hold the write lock.
+func (rcm *RedisConfigManager) setLastError(err error) {
+	rcm.lastErr = err
+	rcm.loads++
+	if err != nil {
+		rcm.loadErrors++
+	}
+
+	switch {
+	case err != nil && rcm.degradedSince.IsZero():
+		rcm.degradedSince = rcm.now()
+	case err == nil && !rcm.degradedSince.IsZero():
+		rcm.degradedTotal += rcm.now().Sub(rcm.degradedSince)
+		rcm.degradedSince = time.Time{}
+	}
+}
+
+// reload runs LoadConfig for the background loops and reports failures to
+// the OnLoadError callbacks.
+func (rcm *RedisConfigManager) reload(l loop) error {
+	err := rcm.loadConfig(l.ctx, l.client)
+	if err == nil || l.ctx.Err() != nil {
+		return err
+	}
+
+	rcm.log().Errorf("failed to reload config %s: %v", rcm.configKey(), err)
+
+	rcm.mu.RLock()
+	callbacks := rcm.onLoadError
+	rcm.mu.RUnlock()
+
+	for _, callback := range callbacks {
+		callback(err)
+	}
+
+	return err
+}
+
+// reloadRecovering runs reload for the background loops and recovers from a
+// panic in plugged-in code such as a validator, decryptor or callback, so
+// that one bad reload does not stop polling. The panic is logged and
+// recorded as the last error.
+func (rcm *RedisConfigManager) reloadRecovering(l loop) {
+	defer func() {
+		if r := recover(); r != nil {
+			err := fmt.Errorf("panic while reloading config: %v", r)
+			rcm.log().Errorf("%v\n%s", err, debug.Stack())
+
+			rcm.mu.Lock()
+			rcm.setLastError(err)
+			rcm.mu.Unlock()
+		}
+	}()
+
+	rcm.reload(l)
+}
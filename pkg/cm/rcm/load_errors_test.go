@@ -0,0 +1,264 @@
+package rcm
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestOnLoadError(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	serviceName := "test_service"
+	if err := mr.Set(serviceName, "invalid json"); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+
+	rcm := &RedisConfigManager{
+		serviceName: serviceName,
+		config:      make(map[string]string),
+		r:           client,
+	}
+	rcm.ctx, rcm.cancel = context.WithCancel(context.Background())
+
+	errs := make(chan error, 10)
+	rcm.OnLoadError(func(err error) {
+		select {
+		case errs <- err:
+		default:
+		}
+	})
+
+	rcm.StartLoading(20 * time.Millisecond)
+	defer rcm.StopLoading()
+
+	select {
+	case err := <-errs:
+		if err == nil {
+			t.Error("expected a non-nil error")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("background load error was not reported")
+	}
+
+	if rcm.LastError() == nil {
+		t.Error("expected LastError to be set after a failed load")
+	}
+}
+
+func TestLastError(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	serviceName := "test_service"
+	rcm := &RedisConfigManager{
+		serviceName: serviceName,
+		config:      make(map[string]string),
+		r:           client,
+		ctx:         context.Background(),
+	}
+
+	if rcm.LastError() != nil {
+		t.Error("expected no error before loading")
+	}
+
+	if err := rcm.LoadConfig(context.Background()); err == nil {
+		t.Fatal("expected LoadConfig to fail for a missing key")
+	}
+	if rcm.LastError() == nil {
+		t.Error("expected LastError after a failed load")
+	}
+
+	if err := mr.Set(serviceName, `{"string_key": "value"}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+	if err := rcm.LoadConfig(context.Background()); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if rcm.LastError() != nil {
+		t.Errorf("expected LastError to clear after a successful load, got %v", rcm.LastError())
+	}
+}
+
+func TestDegraded(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer client.Close()
+
+	serviceName := "test_service"
+	if err := mr.Set(serviceName, `{"string_key": "last_good"}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+
+	rcm := &RedisConfigManager{
+		serviceName: serviceName,
+		config:      make(map[string]string),
+		r:           client,
+		ctx:         context.Background(),
+	}
+
+	if err := rcm.LoadConfig(context.Background()); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if rcm.Degraded() {
+		t.Error("expected not degraded after a successful load")
+	}
+
+	mr.Close()
+
+	if err := rcm.LoadConfig(context.Background()); err == nil {
+		t.Fatal("expected LoadConfig to fail while Redis is down")
+	}
+	if !rcm.Degraded() {
+		t.Error("expected degraded after a failed load")
+	}
+
+	value, err := rcm.GetString("string_key")
+	if err != nil || value != "last_good" {
+		t.Errorf("expected last good value 'last_good', got '%s' (%v)", value, err)
+	}
+
+	if err := mr.Restart(); err != nil {
+		t.Fatalf("failed to restart miniredis: %v", err)
+	}
+	defer mr.Close()
+	if err := mr.Set(serviceName, `{"string_key": "recovered"}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+
+	if err := rcm.LoadConfig(context.Background()); err != nil {
+		t.Fatalf("LoadConfig failed after recovery: %v", err)
+	}
+	if rcm.Degraded() {
+		t.Error("expected not degraded after recovering")
+	}
+}
+
+func TestDegradedDuration(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer client.Close()
+
+	serviceName := "test_service"
+	if err := mr.Set(serviceName, `{"string_key": "value"}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	rcm := NewRedisConfigManagerWithClient(serviceName, client,
+		WithClock(func() time.Time { return now }),
+	).(*RedisConfigManager)
+
+	if err := rcm.LoadConfig(context.Background()); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if since := rcm.DegradedSince(); !since.IsZero() {
+		t.Errorf("expected no degraded time while healthy, got %v", since)
+	}
+
+	mr.Close()
+	outageStart := now
+	if err := rcm.LoadConfig(context.Background()); err == nil {
+		t.Fatal("expected LoadConfig to fail while Redis is down")
+	}
+	now = now.Add(time.Minute)
+	if err := rcm.LoadConfig(context.Background()); err == nil {
+		t.Fatal("expected LoadConfig to fail while Redis is down")
+	}
+	if since := rcm.DegradedSince(); !since.Equal(outageStart) {
+		t.Errorf("expected the outage to start at the first failure, got %v", since)
+	}
+	now = now.Add(time.Minute)
+	if total := rcm.DegradedDuration(); total != 2*time.Minute {
+		t.Errorf("expected the ongoing outage to count, got %v", total)
+	}
+
+	if err := mr.Restart(); err != nil {
+		t.Fatalf("failed to restart miniredis: %v", err)
+	}
+	defer mr.Close()
+	if err := mr.Set(serviceName, `{"string_key": "value"}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+	if err := rcm.LoadConfig(context.Background()); err != nil {
+		t.Fatalf("LoadConfig failed after recovery: %v", err)
+	}
+	if since := rcm.DegradedSince(); !since.IsZero() {
+		t.Errorf("expected the degraded timer to reset after recovering, got %v", since)
+	}
+
+	now = now.Add(time.Hour)
+	if total := rcm.DegradedDuration(); total != 2*time.Minute {
+		t.Errorf("expected 2m degraded in total, got %v", total)
+	}
+}
+
+func TestStartLoading_SurvivesPanickingCallback(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	if err := mr.Set("myservice", `{"value": "v1"}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+
+	rcm := NewRedisConfigManagerWithClient("myservice", client).(*RedisConfigManager)
+
+	seen := make(chan string, 10)
+	rcm.OnChange(func(key, oldValue, newValue string) {
+		if newValue == "boom" {
+			panic("callback failed")
+		}
+		seen <- newValue
+	})
+
+	rcm.StartLoading(20 * time.Millisecond)
+	defer rcm.StopLoading()
+	<-seen
+
+	if err := mr.Set("myservice", `{"value": "boom"}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+	time.Sleep(100 * time.Millisecond)
+
+	if err := mr.Set("myservice", `{"value": "v3"}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+
+	select {
+	case value := <-seen:
+		if value != "v3" {
+			t.Errorf("expected v3, got %q", value)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("polling stopped after a panicking callback")
+	}
+}
+
+func TestReloadRecovering_RecordsPanic(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	if err := mr.Set("myservice", `{"value": "v1"}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+
+	rcm := NewRedisConfigManagerWithClient("myservice", client).(*RedisConfigManager)
+	rcm.SetValidator(func(map[string]string) error {
+		panic("validator failed")
+	})
+
+	rcm.reloadRecovering(rcm.restart())
+
+	if err := rcm.LastError(); err == nil || !strings.Contains(err.Error(), "validator failed") {
+		t.Errorf("expected the panic to be recorded, got %v", err)
+	}
+
+	rcm.SetValidator(nil)
+	if err := rcm.LoadConfig(context.Background()); err != nil {
+		t.Errorf("expected a load after a recovered panic to succeed, got %v", err)
+	}
+}
@@ -0,0 +1,31 @@
+package rcm
+
+// Logger receives diagnostic messages from a RedisConfigManager. Its method
+// set matches common leveled loggers, so most can be adapted in a few lines.
+type Logger interface {
+	Debugf(format string, args ...any)
+	Warnf(format string, args ...any)
+	Errorf(format string, args ...any)
+}
+
+type nopLogger struct{}
+
+func (nopLogger) Debugf(string, ...any) {}
+func (nopLogger) Warnf(string, ...any)  {}
+func (nopLogger) Errorf(string, ...any) {}
+
+// WithLogger makes the manager log loads, rejected configs and background
+// failures to logger. By default nothing is logged.
+func WithLogger(logger Logger) Option {
+	return func(rcm *RedisConfigManager) {
+		rcm.logger = logger
+	}
+}
+
+func (rcm *RedisConfigManager) log() Logger {
+	if rcm.logger == nil {
+		return nopLogger{}
+	}
+
+	return rcm.logger
+}
@@ -0,0 +1,83 @@
+package rcm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type recordingLogger struct {
+	mu       sync.Mutex
+	messages []string
+}
+
+func (l *recordingLogger) record(level, format string, args ...any) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.messages = append(l.messages, level+": "+fmt.Sprintf(format, args...))
+}
+
+func (l *recordingLogger) Debugf(format string, args ...any) { l.record("debug", format, args...) }
+func (l *recordingLogger) Warnf(format string, args ...any)  { l.record("warn", format, args...) }
+func (l *recordingLogger) Errorf(format string, args ...any) { l.record("error", format, args...) }
+
+func (l *recordingLogger) contains(prefix string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, message := range l.messages {
+		if strings.HasPrefix(message, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestWithLogger(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	serviceName := "test_service"
+	if err := mr.Set(serviceName, `{"port": "8080"}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+
+	logger := &recordingLogger{}
+	rcm := &RedisConfigManager{
+		serviceName: serviceName,
+		config:      make(map[string]string),
+		r:           client,
+	}
+	rcm.ctx, rcm.cancel = context.WithCancel(context.Background())
+	defer rcm.cancel()
+	WithLogger(logger)(rcm)
+
+	if err := rcm.LoadConfig(context.Background()); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if !logger.contains("debug: loaded config test_service") {
+		t.Errorf("expected a debug message for the load, got %v", logger.messages)
+	}
+
+	rcm.SetValidator(func(map[string]string) error {
+		return errors.New("port out of range")
+	})
+	rcm.reload(rcm.restart())
+
+	if !logger.contains("warn: config test_service rejected by validator") {
+		t.Errorf("expected a warning for the rejected config, got %v", logger.messages)
+	}
+	if !logger.contains("error: failed to reload config test_service") {
+		t.Errorf("expected an error for the failed reload, got %v", logger.messages)
+	}
+}
+
+func TestNopLoggerByDefault(t *testing.T) {
+	rcm := &RedisConfigManager{}
+	if _, ok := rcm.log().(nopLogger); !ok {
+		t.Errorf("expected the no-op logger by default, got %T", rcm.log())
+	}
+}
@@ -0,0 +1,38 @@
+package rcm
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/zemld/config-manager/pkg/cm"
+)
+
+// WithMaxAge makes the getters report cm.ErrStale when the last successful
+// load is older than maxAge, which points at a stalled refresh loop. The
+// value is still returned with the error, so callers can decide whether to
+// use it; the WithDefault getters keep serving it.
+func WithMaxAge(maxAge time.Duration) Option {
+	return func(rcm *RedisConfigManager) {
+		rcm.maxAge = maxAge
+	}
+}
+
+// checkAge returns err, or a wrapped cm.ErrStale when err is nil and the
+// config is older than the maximum age. It takes the read lock only when a
+// maximum age is set.
+func (rcm *RedisConfigManager) checkAge(err error) error {
+	if err != nil || rcm.maxAge <= 0 {
+		return err
+	}
+
+	updatedAt := rcm.LastUpdated()
+	if updatedAt.IsZero() {
+		return nil
+	}
+
+	if age := rcm.now().Sub(updatedAt); age > rcm.maxAge {
+		return fmt.Errorf("%w: last updated %s ago, max age is %s", cm.ErrStale, age.Round(time.Millisecond), rcm.maxAge)
+	}
+
+	return nil
+}
@@ -0,0 +1,143 @@
+package rcm
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/zemld/config-manager/pkg/cm"
+	"github.com/zemld/config-manager/pkg/cm/chain"
+)
+
+func TestWithMaxAge(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	serviceName := "test_service"
+	if err := mr.Set(serviceName, `{"port": 8080, "mode": "fast"}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+
+	rcm := NewRedisConfigManagerWithClient(serviceName, client, WithMaxAge(time.Minute)).(*RedisConfigManager)
+	if err := rcm.LoadConfig(context.Background()); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	if port, err := rcm.GetInt("port"); err != nil || port != 8080 {
+		t.Fatalf("expected a fresh 8080, got %d (err: %v)", port, err)
+	}
+
+	rcm.mu.Lock()
+	rcm.updatedAt = time.Now().Add(-2 * time.Minute)
+	rcm.mu.Unlock()
+
+	port, err := rcm.GetInt("port")
+	if !errors.Is(err, cm.ErrStale) {
+		t.Errorf("expected ErrStale, got %v", err)
+	}
+	if port != 8080 {
+		t.Errorf("expected the stale value to be returned, got %d", port)
+	}
+
+	if mode, err := rcm.GetEnum("mode", []string{"fast", "slow"}); !errors.Is(err, cm.ErrStale) || mode != "fast" {
+		t.Errorf("expected stale fast, got %q (err: %v)", mode, err)
+	}
+	if _, err := rcm.GetInt("missing"); !errors.Is(err, cm.ErrKeyNotFound) {
+		t.Errorf("expected ErrKeyNotFound to take precedence, got %v", err)
+	}
+	if port := rcm.GetIntWithDefault("port", 1); port != 8080 {
+		t.Errorf("expected WithDefault to serve the stale value, got %d", port)
+	}
+
+	var target struct {
+		Port int `cm:"port"`
+	}
+	if err := rcm.Unmarshal(&target); err != nil || target.Port != 8080 {
+		t.Errorf("expected Unmarshal to fill stale values, got %d (err: %v)", target.Port, err)
+	}
+
+	if err := rcm.LoadConfig(context.Background()); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if _, err := rcm.GetInt("port"); err != nil {
+		t.Errorf("expected a reload to clear staleness, got %v", err)
+	}
+}
+
+func TestWithMaxAge_Clock(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	serviceName := "test_service"
+	if err := mr.Set(serviceName, `{"port": 8080}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+
+	rcm := NewRedisConfigManagerWithClient(serviceName, client, WithMaxAge(time.Minute), WithClock(clock))
+	if err := rcm.LoadConfig(context.Background()); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if !rcm.LastUpdated().Equal(now) {
+		t.Errorf("expected LastUpdated to come from the clock, got %v", rcm.LastUpdated())
+	}
+
+	now = now.Add(time.Minute)
+	if _, err := rcm.GetInt("port"); err != nil {
+		t.Errorf("expected the config to be fresh at exactly max age, got %v", err)
+	}
+
+	now = now.Add(time.Second)
+	if _, err := rcm.GetInt("port"); !errors.Is(err, cm.ErrStale) {
+		t.Errorf("expected ErrStale after advancing the clock, got %v", err)
+	}
+}
+
+func TestWithMaxAge_StaleValueCallers(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	serviceName := "test_service"
+	if err := mr.Set(serviceName, `{"port": 8080, "db": {"host": "localhost"}}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	rcm := NewRedisConfigManagerWithClient(serviceName, client,
+		WithMaxAge(time.Minute), WithClock(func() time.Time { return now }),
+	).(*RedisConfigManager)
+	if err := rcm.LoadConfig(context.Background()); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	now = now.Add(time.Hour)
+
+	db, err := cm.GetJSON[struct{ Host string }](rcm, "db")
+	if !errors.Is(err, cm.ErrStale) || db.Host != "localhost" {
+		t.Errorf("expected GetJSON to decode the stale value, got %+v (err: %v)", db, err)
+	}
+
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				t.Errorf("expected MustGetInt not to panic on a stale value, got %v", r)
+			}
+		}()
+		if port := rcm.MustGetInt("port"); port != 8080 {
+			t.Errorf("expected MustGetInt to return the stale 8080, got %d", port)
+		}
+	}()
+
+	ccm := chain.NewChainConfigManager(rcm)
+	if port := ccm.GetIntWithDefault("port", 1); port != 8080 {
+		t.Errorf("expected the chain to serve the stale value, got %d", port)
+	}
+	if port := ccm.GetIntWithDefault("missing", 1); port != 1 {
+		t.Errorf("expected the default for a missing key, got %d", port)
+	}
+}
@@ -0,0 +1,32 @@
+package rcm
+
+import "time"
+
+// Metrics receives load statistics from a RedisConfigManager. Implementations
+// typically forward them to Prometheus counters and histograms; staleness can
+// be derived from LastUpdated.
+type Metrics interface {
+	IncLoadSuccess()
+	IncLoadError()
+	ObserveLoadDuration(d time.Duration)
+}
+
+// WithMetrics makes the manager report every LoadConfig to m.
+func WithMetrics(m Metrics) Option {
+	return func(rcm *RedisConfigManager) {
+		rcm.metrics = m
+	}
+}
+
+func (rcm *RedisConfigManager) recordLoad(start time.Time, err error) {
+	if rcm.metrics == nil {
+		return
+	}
+
+	rcm.metrics.ObserveLoadDuration(time.Since(start))
+	if err != nil {
+		rcm.metrics.IncLoadError()
+		return
+	}
+	rcm.metrics.IncLoadSuccess()
+}
@@ -0,0 +1,74 @@
+package rcm
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+type fakeMetrics struct {
+	mu        sync.Mutex
+	successes int
+	errors    int
+	durations []time.Duration
+}
+
+func (m *fakeMetrics) IncLoadSuccess() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.successes++
+}
+
+func (m *fakeMetrics) IncLoadError() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.errors++
+}
+
+func (m *fakeMetrics) ObserveLoadDuration(d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.durations = append(m.durations, d)
+}
+
+func TestWithMetrics(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	serviceName := "test_service"
+	metrics := &fakeMetrics{}
+
+	rcm := &RedisConfigManager{
+		serviceName: serviceName,
+		config:      make(map[string]string),
+		r:           client,
+		ctx:         context.Background(),
+	}
+	WithMetrics(metrics)(rcm)
+
+	if err := rcm.LoadConfig(context.Background()); err == nil {
+		t.Fatal("expected LoadConfig to fail for a missing key")
+	}
+
+	if err := mr.Set(serviceName, `{"string_key": "value"}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+	if err := rcm.LoadConfig(context.Background()); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if err := rcm.LoadConfig(context.Background()); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	if metrics.successes != 2 {
+		t.Errorf("expected 2 successes, got %d", metrics.successes)
+	}
+	if metrics.errors != 1 {
+		t.Errorf("expected 1 error, got %d", metrics.errors)
+	}
+	if len(metrics.durations) != 3 {
+		t.Errorf("expected 3 observed durations, got %d", len(metrics.durations))
+	}
+}
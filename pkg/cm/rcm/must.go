@@ -0,0 +1,58 @@
+package rcm
+
+import (
+	"errors"
+	"time"
+
+	"github.com/zemld/config-manager/pkg/cm"
+	"github.com/zemld/config-manager/pkg/cm/internal/conv"
+)
+
+// The MustGet methods panic where the getters would return an error; see
+// the cm package documentation. A stale value is still returned, as the
+// WithDefault getters do.
+
+func (rcm *RedisConfigManager) MustGetString(key string) string {
+	value, err := rcm.GetString(key)
+	if err != nil && !errors.Is(err, cm.ErrStale) {
+		panic(conv.MustMessage(key, err))
+	}
+
+	return value
+}
+
+func (rcm *RedisConfigManager) MustGetInt(key string) int {
+	value, err := rcm.GetInt(key)
+	if err != nil && !errors.Is(err, cm.ErrStale) {
+		panic(conv.MustMessage(key, err))
+	}
+
+	return value
+}
+
+func (rcm *RedisConfigManager) MustGetFloat(key string) float64 {
+	value, err := rcm.GetFloat(key)
+	if err != nil && !errors.Is(err, cm.ErrStale) {
+		panic(conv.MustMessage(key, err))
+	}
+
+	return value
+}
+
+func (rcm *RedisConfigManager) MustGetBool(key string) bool {
+	value, err := rcm.GetBool(key)
+	if err != nil && !errors.Is(err, cm.ErrStale) {
+		panic(conv.MustMessage(key, err))
+	}
+
+	return value
+}
+
+func (rcm *RedisConfigManager) MustGetDuration(key string) time.Duration {
+	value, err := rcm.GetDuration(key)
+	if err != nil && !errors.Is(err, cm.ErrStale) {
+		panic(conv.MustMessage(key, err))
+	}
+
+	return value
+}
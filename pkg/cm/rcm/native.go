@@ -0,0 +1,80 @@
+package rcm
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/zemld/config-manager/pkg/cm"
+)
+
+// WithStrictTypes makes GetString, GetInt, GetFloat and GetBool check the
+// type a value had in the source document instead of parsing its text, the
+// way the in-memory manager does. With it, a JSON number is not a string and
+// a quoted "42" is not an int. Values from a hash backend have no type
+// beyond string, so this suits document backends.
+func WithStrictTypes(strict bool) Option {
+	return func(rcm *RedisConfigManager) {
+		rcm.strictTypes = strict
+	}
+}
+
+// GetValue returns the value of key as it was decoded from the source: a
+// string, bool, json.Number, map[string]any or []any for JSON documents, or
+// the Go value passed to Override or SetDefaults.
+func (rcm *RedisConfigManager) GetValue(key string) (any, error) {
+	view := rcm.view()
+
+	value, ok := view.native(key)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", cm.ErrKeyNotFound, key)
+	}
+
+	return value, rcm.checkAge(nil)
+}
+
+// checkNative returns cm.ErrTypeMismatch when strict types are enabled and
+// the decoded value of key does not match kind. Missing keys are left to
+// the getter.
+func (rcm *RedisConfigManager) checkNative(v *view, key, kind string) error {
+	if !rcm.strictTypes {
+		return nil
+	}
+
+	value, ok := v.native(key)
+	if !ok || nativeIs(value, kind) {
+		return nil
+	}
+
+	switch kind {
+	case "int":
+		return fmt.Errorf("key %s is not an int: %w", key, cm.ErrTypeMismatch)
+	default:
+		return fmt.Errorf("key %s is not a %s: %w", key, kind, cm.ErrTypeMismatch)
+	}
+}
+
+func nativeIs(value any, kind string) bool {
+	switch kind {
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "bool":
+		_, ok := value.(bool)
+		return ok
+	case "int":
+		switch number := value.(type) {
+		case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+			return true
+		case json.Number:
+			_, err := number.Int64()
+			return err == nil
+		}
+	case "float":
+		switch value.(type) {
+		case float32, float64, json.Number:
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,88 @@
+package rcm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/zemld/config-manager/pkg/cm"
+	"github.com/zemld/config-manager/pkg/cm/mcm"
+)
+
+func TestWithStrictTypes_MatchesInMemory(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	if err := mr.Set("myservice", `{"port": 8080, "ratio": 0.5, "name": "svc", "debug": true, "quoted": "42"}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+
+	strict := NewRedisConfigManagerWithClient("myservice", client, WithStrictTypes(true)).(*RedisConfigManager)
+	if err := strict.LoadConfig(context.Background()); err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	mock := mcm.NewMockConfigManager(map[string]any{
+		"port": 8080, "ratio": 0.5, "name": "svc", "debug": true, "quoted": "42",
+	})
+
+	managers := map[string]cm.ConfigGetter{"redis": strict, "in-memory": mock}
+	for name, manager := range managers {
+		if port, err := manager.GetInt("port"); err != nil || port != 8080 {
+			t.Errorf("%s: expected port 8080, got %d (err: %v)", name, port, err)
+		}
+		if ratio, err := manager.GetFloat("ratio"); err != nil || ratio != 0.5 {
+			t.Errorf("%s: expected ratio 0.5, got %v (err: %v)", name, ratio, err)
+		}
+		if debug, err := manager.GetBool("debug"); err != nil || !debug {
+			t.Errorf("%s: expected debug to be true, got %v (err: %v)", name, debug, err)
+		}
+		if _, err := manager.GetInt("quoted"); !errors.Is(err, cm.ErrTypeMismatch) {
+			t.Errorf("%s: expected a quoted number not to be an int, got %v", name, err)
+		}
+		if _, err := manager.GetString("port"); !errors.Is(err, cm.ErrTypeMismatch) {
+			t.Errorf("%s: expected a number not to be a string, got %v", name, err)
+		}
+		if _, err := manager.GetBool("name"); !errors.Is(err, cm.ErrTypeMismatch) {
+			t.Errorf("%s: expected a string not to be a bool, got %v", name, err)
+		}
+	}
+}
+
+func TestGetValue(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	if err := mr.Set("myservice", `{"port": 8080, "quoted": "42", "db": {"host": "localhost"}}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+
+	rcm := NewRedisConfigManagerWithClient("myservice", client).(*RedisConfigManager)
+	if err := rcm.LoadConfig(context.Background()); err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	rcm.Override("debug", true)
+
+	if value, err := rcm.GetValue("port"); err != nil || value != json.Number("8080") {
+		t.Errorf("expected json.Number 8080, got %#v (err: %v)", value, err)
+	}
+	if value, _ := rcm.GetValue("quoted"); value != "42" {
+		t.Errorf("expected the string 42, got %#v", value)
+	}
+	if value, _ := rcm.GetValue("db"); value == nil {
+		t.Error("expected the object for db")
+	} else if _, ok := value.(map[string]any); !ok {
+		t.Errorf("expected a map for db, got %T", value)
+	}
+	if value, _ := rcm.GetValue("debug"); value != true {
+		t.Errorf("expected the override to keep its Go type, got %#v", value)
+	}
+	if port, err := rcm.GetInt("quoted"); err != nil || port != 42 {
+		t.Errorf("expected lenient parsing without strict types, got %d (err: %v)", port, err)
+	}
+	if _, err := rcm.GetValue("missing"); !errors.Is(err, cm.ErrKeyNotFound) {
+		t.Errorf("expected ErrKeyNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,78 @@
+package rcm
+
+import "errors"
+
+// Option configures a RedisConfigManager at construction time.
+type Option func(*RedisConfigManager)
+
+// WithKey makes the manager read its config from key instead of the service
+// name. The service name is still used to identify the manager.
+func WithKey(key string) Option {
+	return func(rcm *RedisConfigManager) {
+		rcm.key = key
+	}
+}
+
+// WithFallbacks makes the manager fall through to the fallback keys, in
+// order, for keys its own config lacks. Objects are merged recursively and
+// missing fallback keys are skipped.
+func WithFallbacks(keys ...string) Option {
+	return func(rcm *RedisConfigManager) {
+		rcm.fallbacks = keys
+	}
+}
+
+// WithNamespace prefixes the config key with ns, such as "tenantA:", so
+// tenants sharing a Redis cluster do not collide. It applies to the service
+// name or to the key set by WithKey or SetKey. Keys added with AddSource and
+// the keys of WithFallbacks are used as given.
+func WithNamespace(ns string) Option {
+	return func(rcm *RedisConfigManager) {
+		rcm.namespace = ns
+	}
+}
+
+// SetKey points the manager at a different Redis key, for example to flip a
+// service to a new config version, and reloads from it immediately. Loads
+// still in flight from the old key are discarded. If the reload fails, the
+// previous config keeps being served and later reloads use the new key.
+// StartWatching keeps watching the key it was started with.
+func (rcm *RedisConfigManager) SetKey(key string) error {
+	if key == "" {
+		return errors.New("config key must not be empty")
+	}
+
+	rcm.loadMu.Lock()
+	rcm.mu.Lock()
+	rcm.key = key
+	rcm.mu.Unlock()
+	rcm.loadMu.Unlock()
+
+	return rcm.LoadConfig(rcm.context())
+}
+
+// WithTolerateMissingKey controls whether a config key that does not exist
+// in Redis is loaded as an empty config instead of failing LoadConfig. This
+// lets services start before their config has been published.
+func WithTolerateMissingKey(tolerate bool) Option {
+	return func(rcm *RedisConfigManager) {
+		rcm.tolerateMissing = tolerate
+	}
+}
+
+// WithMergeOnLoad controls whether keys deleted from the source stay in the
+// loaded config. By default every load replaces the whole config, so
+// deletions propagate; merging keeps the previous value of removed keys.
+func WithMergeOnLoad(merge bool) Option {
+	return func(rcm *RedisConfigManager) {
+		rcm.mergeOnLoad = merge
+	}
+}
+
+// WithDecimalComma makes GetFloat accept a comma as the decimal separator,
+// so "3,14" reads as 3.14. By default only a dot is accepted.
+func WithDecimalComma(enabled bool) Option {
+	return func(rcm *RedisConfigManager) {
+		rcm.decimalComma = enabled
+	}
+}
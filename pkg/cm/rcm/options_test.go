@@ -0,0 +1,439 @@
+package rcm
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+	"unicode"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/zemld/config-manager/pkg/cm"
+)
+
+func TestWithKey(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	if err := mr.Set("config:prod:myservice:v2", `{"string_key": "from_key"}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+	if err := mr.Set("myservice", `{"string_key": "from_service_name"}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+
+	rcm := NewRedisConfigManager("myservice", &redis.Options{
+		Addr: mr.Addr(),
+	}, WithKey("config:prod:myservice:v2"))
+	defer rcm.StopLoading()
+
+	if err := rcm.LoadConfig(context.Background()); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	value, err := rcm.GetString("string_key")
+	if err != nil || value != "from_key" {
+		t.Errorf("expected 'from_key', got '%s' (%v)", value, err)
+	}
+
+	if name := rcm.(*RedisConfigManager).serviceName; name != "myservice" {
+		t.Errorf("expected serviceName to stay 'myservice', got '%s'", name)
+	}
+}
+
+func TestConfigKey_DefaultsToServiceName(t *testing.T) {
+	rcm := &RedisConfigManager{serviceName: "myservice"}
+
+	if key := rcm.configKey(); key != "myservice" {
+		t.Errorf("expected 'myservice', got '%s'", key)
+	}
+}
+
+func TestWithNamespace(t *testing.T) {
+	tests := []struct {
+		name string
+		rcm  *RedisConfigManager
+		want string
+	}{
+		{"service name", &RedisConfigManager{serviceName: "myservice", namespace: "tenantA:"}, "tenantA:myservice"},
+		{"with key", &RedisConfigManager{serviceName: "myservice", key: "config:v2", namespace: "tenantA:"}, "tenantA:config:v2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if key := tt.rcm.configKey(); key != tt.want {
+				t.Errorf("expected '%s', got '%s'", tt.want, key)
+			}
+		})
+	}
+
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	if err := mr.Set("tenantA:myservice", `{"string_key": "tenant_a"}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+	if err := mr.Set("myservice", `{"string_key": "shared"}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+
+	rcm := NewRedisConfigManagerWithClient("myservice", client, WithNamespace("tenantA:"))
+	if err := rcm.LoadConfig(context.Background()); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if value, err := rcm.GetString("string_key"); err != nil || value != "tenant_a" {
+		t.Errorf("expected 'tenant_a', got '%s' (%v)", value, err)
+	}
+}
+
+func TestWithDefaultsKey(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	if err := mr.Set("defaults", `{"timeout": "5s", "region": "eu", "db": {"host": "shared-db", "port": 5432}}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+	if err := mr.Set("myservice", `{"timeout": "1s", "db": {"port": 6543}}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+
+	rcm := NewRedisConfigManagerWithClient("myservice", client, WithDefaultsKey("defaults"))
+	if err := rcm.LoadConfig(context.Background()); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	expected := map[string]string{
+		"timeout": "1s",
+		"region":  "eu",
+		"db.host": "shared-db",
+		"db.port": "6543",
+	}
+	for key, want := range expected {
+		if value, err := rcm.GetString(key); err != nil || value != want {
+			t.Errorf("GetString(%s): expected '%s', got '%s' (%v)", key, want, value, err)
+		}
+	}
+
+	mr.Del("defaults")
+	if err := rcm.LoadConfig(context.Background()); err != nil {
+		t.Fatalf("expected a missing defaults key to be tolerated, got %v", err)
+	}
+	if _, err := rcm.GetString("region"); !errors.Is(err, cm.ErrKeyNotFound) {
+		t.Errorf("expected region to be gone with the defaults, got %v", err)
+	}
+}
+
+func TestWithCaseInsensitiveKeys(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	if err := mr.Set("myservice", `{"MaxConns": 10, "DB": {"Host": "localhost"}, "Region": "eu", "region": "us"}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+
+	t.Run("default is case-sensitive", func(t *testing.T) {
+		rcm := NewRedisConfigManagerWithClient("myservice", client)
+		if err := rcm.LoadConfig(context.Background()); err != nil {
+			t.Fatalf("LoadConfig failed: %v", err)
+		}
+
+		if value, err := rcm.GetInt("MaxConns"); err != nil || value != 10 {
+			t.Errorf("expected 10, got %d (%v)", value, err)
+		}
+		if _, err := rcm.GetInt("maxconns"); !errors.Is(err, cm.ErrKeyNotFound) {
+			t.Errorf("expected ErrKeyNotFound for a different case, got %v", err)
+		}
+	})
+
+	t.Run("case-insensitive", func(t *testing.T) {
+		logger := &recordingLogger{}
+		rcm := NewRedisConfigManagerWithClient("myservice", client,
+			WithCaseInsensitiveKeys(true), WithLogger(logger),
+		).(*RedisConfigManager)
+		if err := rcm.LoadConfig(context.Background()); err != nil {
+			t.Fatalf("LoadConfig failed: %v", err)
+		}
+
+		for _, key := range []string{"MaxConns", "maxconns", "MAXCONNS"} {
+			if value, err := rcm.GetInt(key); err != nil || value != 10 {
+				t.Errorf("GetInt(%s): expected 10, got %d (%v)", key, value, err)
+			}
+		}
+		if value, err := rcm.GetString("db.HOST"); err != nil || value != "localhost" {
+			t.Errorf("expected nested keys to be folded, got '%s' (%v)", value, err)
+		}
+		if value, err := rcm.GetString("Region"); err != nil || value != "us" {
+			t.Errorf("expected the key sorting last to win, got '%s' (%v)", value, err)
+		}
+		if !logger.contains("warn: keys Region and region differ only in case") {
+			t.Errorf("expected a collision warning, got %v", logger.messages)
+		}
+
+		rcm.Override("MaxConns", 20)
+		if value, err := rcm.GetInt("maxconns"); err != nil || value != 20 {
+			t.Errorf("expected the override to be folded, got %d (%v)", value, err)
+		}
+		rcm.ClearOverride("MAXCONNS")
+		if value, err := rcm.GetInt("maxconns"); err != nil || value != 10 {
+			t.Errorf("expected the override to be cleared, got %d (%v)", value, err)
+		}
+	})
+}
+
+func TestWithDefaultsKey_Set(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	if err := mr.Set("defaults", `{"a": 1, "b": 2}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+	if err := mr.Set("myservice", `{"b": 20}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+
+	rcm := NewRedisConfigManagerWithClient("myservice", client, WithDefaultsKey("defaults")).(*RedisConfigManager)
+	if err := rcm.LoadConfig(context.Background()); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if err := rcm.Set(context.Background(), "c", 3); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+
+	expected := map[string]int{"a": 1, "b": 20, "c": 3}
+	for key, want := range expected {
+		if value, err := rcm.GetInt(key); err != nil || value != want {
+			t.Errorf("GetInt(%s): expected %d after Set, got %d (%v)", key, want, value, err)
+		}
+	}
+}
+
+func TestWithMergeOnLoad(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	serviceName := "test_service"
+	if err := mr.Set(serviceName, `{"kept_key": "1", "removed_key": "2"}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+
+	rcm := NewRedisConfigManager(serviceName, &redis.Options{
+		Addr: mr.Addr(),
+	}, WithMergeOnLoad(true))
+	defer rcm.StopLoading()
+
+	if err := rcm.LoadConfig(context.Background()); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	if err := mr.Set(serviceName, `{"kept_key": "10"}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+	if err := rcm.LoadConfig(context.Background()); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	if value, err := rcm.GetString("removed_key"); err != nil || value != "2" {
+		t.Errorf("expected removed_key to be kept as '2', got '%s' (%v)", value, err)
+	}
+	if value, err := rcm.GetString("kept_key"); err != nil || value != "10" {
+		t.Errorf("expected kept_key to be updated to '10', got '%s' (%v)", value, err)
+	}
+}
+
+func TestWithTolerateMissingKey(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	strict := NewRedisConfigManagerWithClient("myservice", client)
+	if err := strict.LoadConfig(context.Background()); !errors.Is(err, redis.Nil) {
+		t.Errorf("expected redis.Nil for a missing key by default, got %v", err)
+	}
+
+	tolerant := NewRedisConfigManagerWithClient("myservice", client, WithTolerateMissingKey(true))
+	if err := tolerant.LoadConfig(context.Background()); err != nil {
+		t.Fatalf("expected a missing key to load as an empty config, got %v", err)
+	}
+	if keys := tolerant.(*RedisConfigManager).Keys(); len(keys) != 0 {
+		t.Errorf("expected an empty config, got keys %v", keys)
+	}
+
+	if err := mr.Set("myservice", `{"string_key": "published"}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+	if err := tolerant.LoadConfig(context.Background()); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if value, _ := tolerant.GetString("string_key"); value != "published" {
+		t.Errorf("expected the published config to load, got %q", value)
+	}
+}
+
+func TestSetKey(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	if err := mr.Set("myservice:v1", `{"version": "v1"}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+	if err := mr.Set("myservice:v2", `{"version": "v2"}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+
+	rcm := NewRedisConfigManagerWithClient("myservice", client, WithKey("myservice:v1")).(*RedisConfigManager)
+	if err := rcm.LoadConfig(context.Background()); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	if err := rcm.SetKey(""); err == nil {
+		t.Error("expected an error for an empty key")
+	}
+
+	if err := rcm.SetKey("myservice:v2"); err != nil {
+		t.Fatalf("SetKey failed: %v", err)
+	}
+	if version, _ := rcm.GetString("version"); version != "v2" {
+		t.Errorf("expected SetKey to reload from the new key, got %q", version)
+	}
+
+	if err := rcm.SetKey("myservice:v3"); err == nil {
+		t.Error("expected an error for a missing key")
+	}
+	if version, _ := rcm.GetString("version"); version != "v2" {
+		t.Errorf("expected the previous config to be kept, got %q", version)
+	}
+}
+
+func TestApplyConfig_DropsDocumentFromOldKey(t *testing.T) {
+	rcm := &RedisConfigManager{
+		serviceName: "myservice",
+		config:      make(map[string]string),
+		key:         "myservice:v2",
+	}
+
+	if err := rcm.applyConfig("myservice:v1", "", map[string]any{"version": "v1"}); err != nil {
+		t.Fatalf("applyConfig failed: %v", err)
+	}
+	if _, err := rcm.GetString("version"); err == nil {
+		t.Error("expected a document from a replaced key to be dropped")
+	}
+}
+
+func TestWithKeyTransform(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	if err := mr.Set("myservice", `{"maxConns": 10, "read-timeout": "5s", "dbConfig": {"hostName": "x"}}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+
+	snakeCase := func(key string) string {
+		var b strings.Builder
+		for i, r := range key {
+			switch {
+			case r == '-':
+				b.WriteRune('_')
+			case unicode.IsUpper(r):
+				if i > 0 {
+					b.WriteRune('_')
+				}
+				b.WriteRune(unicode.ToLower(r))
+			default:
+				b.WriteRune(r)
+			}
+		}
+		return b.String()
+	}
+
+	rcm := NewRedisConfigManagerWithClient("myservice", client, WithKeyTransform(snakeCase))
+	if err := rcm.LoadConfig(context.Background()); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	if value, err := rcm.GetInt("max_conns"); err != nil || value != 10 {
+		t.Errorf("expected 10, got %d (err: %v)", value, err)
+	}
+	if value, err := rcm.GetDuration("read_timeout"); err != nil || value != 5*time.Second {
+		t.Errorf("expected 5s, got %v (err: %v)", value, err)
+	}
+	if value, err := rcm.GetString("db_config.host_name"); err != nil || value != "x" {
+		t.Errorf("expected x, got %q (err: %v)", value, err)
+	}
+	if _, err := rcm.GetInt("maxConns"); !errors.Is(err, cm.ErrKeyNotFound) {
+		t.Errorf("expected the original key to be gone, got %v", err)
+	}
+
+	if err := mr.Set("myservice", `{"read-timeout": "1s", "read_timeout": "2s"}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+	if err := rcm.LoadConfig(context.Background()); err == nil {
+		t.Error("expected a key collision to fail the load")
+	}
+	if value, _ := rcm.GetInt("max_conns"); value != 10 {
+		t.Errorf("expected the previous config to be kept, got %d", value)
+	}
+}
+
+func TestWithDecimalComma(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	if err := mr.Set("myservice", `{"comma": "3,14", "dot": "2.5"}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+
+	strict := NewRedisConfigManagerWithClient("myservice", client)
+	if err := strict.LoadConfig(context.Background()); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if _, err := strict.GetFloat("comma"); err == nil {
+		t.Error("expected the default mode to reject a decimal comma")
+	}
+
+	lenient := NewRedisConfigManagerWithClient("myservice", client, WithDecimalComma(true))
+	if err := lenient.LoadConfig(context.Background()); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if value, err := lenient.GetFloat("comma"); err != nil || value != 3.14 {
+		t.Errorf("expected 3.14, got %v (err: %v)", value, err)
+	}
+	if value, err := lenient.GetFloat("dot"); err != nil || value != 2.5 {
+		t.Errorf("expected 2.5, got %v (err: %v)", value, err)
+	}
+}
+
+func TestWithFallbacks_OtherOptions(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	if err := mr.Set("service", `{"Timeout": "1s"}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+	if err := mr.Set("shared", `{"Region": "eu", "Timeout": "3s"}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+
+	rcm := NewRedisConfigManagerWithClient("service", client,
+		WithFallbacks("shared"), WithKeyTransform(strings.ToLower),
+	)
+	if err := rcm.LoadConfig(context.Background()); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	if value, err := rcm.GetString("region"); err != nil || value != "eu" {
+		t.Errorf("expected the transformed fallback key, got '%s' (%v)", value, err)
+	}
+	if value, err := rcm.GetString("timeout"); err != nil || value != "1s" {
+		t.Errorf("expected the service key to win, got '%s' (%v)", value, err)
+	}
+}
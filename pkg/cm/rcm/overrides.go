@@ -0,0 +1,70 @@
+package rcm
+
+import (
+	"maps"
+	"strings"
+
+	"github.com/zemld/config-manager/pkg/cm/internal/conv"
+)
+
+// Override pins key to value in this process only, taking precedence over
+// the loaded config and registered defaults until it is cleared. Objects
+// and lists are expanded into dotted keys like loaded values.
+func (rcm *RedisConfigManager) Override(key string, value any) {
+	rcm.mu.Lock()
+	defer rcm.mu.Unlock()
+
+	rcm.cloneOverrides()
+	rcm.clearOverride(key)
+	values, natives := rcm.foldKeys(conv.FlattenNative(map[string]any{key: value}))
+	for overrideKey, overrideValue := range values {
+		rcm.overrides[overrideKey] = overrideValue
+		rcm.overrideNatives[overrideKey] = natives[overrideKey]
+	}
+	rcm.publish()
+}
+
+// ClearOverride removes the override for key, including the dotted keys it
+// expanded into.
+func (rcm *RedisConfigManager) ClearOverride(key string) {
+	rcm.mu.Lock()
+	defer rcm.mu.Unlock()
+
+	rcm.cloneOverrides()
+	rcm.clearOverride(key)
+	rcm.publish()
+}
+
+// ClearOverrides removes every override.
+func (rcm *RedisConfigManager) ClearOverrides() {
+	rcm.mu.Lock()
+	defer rcm.mu.Unlock()
+
+	rcm.overrides = nil
+	rcm.overrideNatives = nil
+	rcm.publish()
+}
+
+func (rcm *RedisConfigManager) clearOverride(key string) {
+	key = rcm.foldKey(key)
+	delete(rcm.overrides, key)
+	delete(rcm.overrideNatives, key)
+	for overrideKey := range rcm.overrides {
+		if strings.HasPrefix(overrideKey, key+".") {
+			delete(rcm.overrides, overrideKey)
+			delete(rcm.overrideNatives, overrideKey)
+		}
+	}
+}
+
+// cloneOverrides replaces the override maps with copies that may be
+// modified, since the published view still references the old ones.
+func (rcm *RedisConfigManager) cloneOverrides() {
+	overrides := make(map[string]string, len(rcm.overrides))
+	overrideNatives := make(map[string]any, len(rcm.overrideNatives))
+	maps.Copy(overrides, rcm.overrides)
+	maps.Copy(overrideNatives, rcm.overrideNatives)
+
+	rcm.overrides = overrides
+	rcm.overrideNatives = overrideNatives
+}
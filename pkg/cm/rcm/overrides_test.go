@@ -0,0 +1,53 @@
+package rcm
+
+import (
+	"context"
+	"testing"
+)
+
+func TestOverride(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	serviceName := "test_service"
+	if err := mr.Set(serviceName, `{"port": 8080, "db": {"host": "prod"}}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+
+	rcm := NewRedisConfigManagerWithClient(serviceName, client, WithParsedCache(true)).(*RedisConfigManager)
+	if err := rcm.LoadConfig(context.Background()); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	if port, _ := rcm.GetInt("port"); port != 8080 {
+		t.Fatalf("expected 8080, got %d", port)
+	}
+
+	rcm.Override("port", 9090)
+	rcm.Override("db", map[string]any{"host": "localhost"})
+
+	if port, _ := rcm.GetInt("port"); port != 9090 {
+		t.Errorf("expected the override 9090, got %d", port)
+	}
+	if host, _ := rcm.GetString("db.host"); host != "localhost" {
+		t.Errorf("expected the nested override localhost, got %q", host)
+	}
+
+	if err := rcm.LoadConfig(context.Background()); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if port, _ := rcm.GetInt("port"); port != 9090 {
+		t.Errorf("expected the override to survive a reload, got %d", port)
+	}
+
+	rcm.ClearOverride("db")
+	if host, _ := rcm.GetString("db.host"); host != "prod" {
+		t.Errorf("expected the loaded value after ClearOverride, got %q", host)
+	}
+
+	rcm.ClearOverrides()
+	if port, _ := rcm.GetInt("port"); port != 8080 {
+		t.Errorf("expected the loaded value after ClearOverrides, got %d", port)
+	}
+}
@@ -2,168 +2,1024 @@ package rcm
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
+	"image/color"
+	"math/rand/v2"
+	"net"
+	"net/url"
 	"os"
-	"strconv"
+	"regexp"
+	"sort"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/redis/go-redis/v9"
 	"github.com/zemld/config-manager/pkg/cm"
+	"github.com/zemld/config-manager/pkg/cm/internal/bind"
+	"github.com/zemld/config-manager/pkg/cm/internal/conv"
 )
 
 type RedisConfigManager struct {
-	once sync.Once
-	r    *redis.Client
+	once       sync.Once
+	r          redis.UniversalClient
+	newClient  func() redis.UniversalClient
+	ownsClient bool
 
-	ctx    context.Context
-	cancel context.CancelFunc
-	wg     sync.WaitGroup
+	parent  context.Context
+	ctx     context.Context
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+	started atomic.Bool
+	polling atomic.Int32
+	stopped bool
+	// run counts restarts, so that a stop finishing late can tell whether a
+	// newer run owns the watchers and the started flag.
+	run      uint64
+	stopping chan struct{}
+
+	loadMu sync.Mutex
+
+	mu              sync.RWMutex
+	serviceName     string
+	key             string
+	namespace       string
+	versionKey      string
+	hash            bool
+	mergeOnLoad     bool
+	keyTransform    func(string) string
+	foldCase        bool
+	tolerateMissing bool
+	format          Format
+	decoder         Decoder
+	decimalComma    bool
+	strictTypes     bool
+	compression     Compression
+	metrics         Metrics
+	audit           AuditSink
+	maxAge          time.Duration
+	retryAttempts   int
+	retryDelay      time.Duration
+	decryptor       func(string) (string, error)
+	cacheParsed     bool
+	parsed          *sync.Map
+	current         atomic.Pointer[view]
+	logger          Logger
+	clock           func() time.Time
+	config          map[string]string
+	natives         map[string]any
+	keyErrors       map[string]error
+	lazy            map[string]string
+	updatedAt       time.Time
+	keyUpdates      map[string]time.Time
+	lastChanged     []string
+	appliedVersion  string
+	versionedKey    string
+	defaults        map[string]string
+	defaultNatives  map[string]any
+	overrides       map[string]string
+	overrideNatives map[string]any
+	fallbacks       []string
+	defaultsKey     string
+	sources         []source
+	validator       func(map[string]string) error
+	keyChecks       map[string]func(string) error
+	onChange        []ChangeFunc
+	watchers        []chan struct{}
+	keyWatchers     map[string][]chan string
+	onLoadError     []func(error)
+	lastErr         error
+	degradedSince   time.Time
+	degradedTotal   time.Duration
+	loads           int64
+	loadErrors      int64
+}
 
-	mu          sync.RWMutex
-	serviceName string
-	config      map[string]string
-	updatedAt   time.Time
+func NewRedisConfigManager(serviceName string, redisOptions *redis.Options, opts ...Option) cm.ConfigManager {
+	return newOwningManager(context.Background(), serviceName, func() redis.UniversalClient {
+		return redis.NewClient(redisOptions)
+	}, opts)
 }
 
-func NewRedisConfigManager(serviceName string, redisOptions *redis.Options) cm.ConfigManager {
+// NewRedisUniversalConfigManager creates a manager for any Redis deployment
+// supported by redis.NewUniversalClient: a single node, a Sentinel-managed
+// failover group when MasterName is set, or a cluster when several Addrs
+// are given. Watching is not supported against a cluster, because keyspace
+// notifications are only delivered by the node owning the key; use polling.
+func NewRedisUniversalConfigManager(serviceName string, redisOptions *redis.UniversalOptions, opts ...Option) cm.ConfigManager {
+	return newOwningManager(context.Background(), serviceName, func() redis.UniversalClient {
+		return redis.NewUniversalClient(redisOptions)
+	}, opts)
+}
+
+// NewRedisConfigManagerWithContext works like NewRedisConfigManager but
+// derives the manager's context from ctx, so cancelling ctx stops polling
+// and watching as StopLoading would. StopLoading still works on its own and
+// is needed to close the client.
+func NewRedisConfigManagerWithContext(ctx context.Context, serviceName string, redisOptions *redis.Options, opts ...Option) cm.ConfigManager {
+	return newOwningManager(ctx, serviceName, func() redis.UniversalClient {
+		return redis.NewClient(redisOptions)
+	}, opts)
+}
+
+// newOwningManager creates a manager with a client of its own, which
+// StopLoading closes and a restart reopens through newClient.
+func newOwningManager(ctx context.Context, serviceName string, newClient func() redis.UniversalClient, opts []Option) *RedisConfigManager {
 	rcm := &RedisConfigManager{
 		serviceName: serviceName,
 		config:      make(map[string]string),
+		parent:      ctx,
+	}
+
+	for _, opt := range opts {
+		opt(rcm)
 	}
 
 	rcm.once.Do(func() {
-		r := redis.NewClient(redisOptions)
-		status := r.Ping(context.Background())
+		r := newClient()
+		status := r.Ping(ctx)
 		if status.Err() != nil {
 			os.Exit(1)
 		}
 		rcm.r = r
+		rcm.newClient = newClient
+		rcm.ownsClient = true
 	})
 
+	rcm.ctx, rcm.cancel = context.WithCancel(ctx)
+	return rcm
+}
+
+// NewRedisConfigManagerWithClient creates a manager that reads its config
+// through an existing client instead of creating one, which may also be a
+// cluster or failover client. The client is not pinged, and StopLoading
+// leaves it open for the caller to close.
+func NewRedisConfigManagerWithClient(serviceName string, client redis.UniversalClient, opts ...Option) cm.ConfigManager {
+	rcm := &RedisConfigManager{
+		serviceName: serviceName,
+		config:      make(map[string]string),
+		r:           client,
+	}
+
+	for _, opt := range opts {
+		opt(rcm)
+	}
+
 	rcm.ctx, rcm.cancel = context.WithCancel(context.Background())
 	return rcm
 }
 
+// StartLoading loads the config and then reloads it every interval until
+// StopLoading is called. Calling it again while loading is a no-op; calling
+// it after StopLoading resumes polling. A duration stored in the config under
+// RefreshIntervalKey takes precedence over interval from the next reload on.
+// An interval that is zero or negative polls every second.
 func (rcm *RedisConfigManager) StartLoading(interval time.Duration) {
+	rcm.startLoading(interval, 0)
+}
+
+// StartLoadingWithInitialLoad works like StartLoading but returns the error
+// of the initial load, so the caller can refuse to start on a missing or
+// broken config. Polling keeps running either way; call StopLoading to
+// abandon it. It returns nil if loading was already started.
+func (rcm *RedisConfigManager) StartLoadingWithInitialLoad(interval time.Duration) error {
+	return rcm.startLoading(interval, 0)
+}
+
+// StartLoadingStrict performs the initial load with ctx and starts polling
+// every interval only if it succeeds. On failure it returns the error and
+// leaves nothing running, so a service can refuse to boot without config.
+// This trades availability for correctness: StartLoading and
+// StartLoadingWithInitialLoad keep polling after a failed first load, so a
+// config published later is still picked up. It returns nil if loading was
+// already started.
+func (rcm *RedisConfigManager) StartLoadingStrict(ctx context.Context, interval time.Duration) error {
+	if !rcm.started.CompareAndSwap(false, true) {
+		return nil
+	}
+	l := rcm.restart()
+
+	if err := rcm.loadConfig(ctx, l.client); err != nil {
+		rcm.started.Store(false)
+		return err
+	}
+
+	rcm.poll(l, interval, 0)
+
+	return nil
+}
+
+// StartLoadingWithJitter works like StartLoading but waits a random duration
+// in [interval-jitter, interval+jitter] before each reload, so that many
+// instances started together do not hit Redis in lockstep. Jitter is capped
+// at half of interval. An interval set under RefreshIntervalKey replaces interval,
+// and the jitter is applied on top of it.
+func (rcm *RedisConfigManager) StartLoadingWithJitter(interval, jitter time.Duration) {
+	rcm.startLoading(interval, jitter)
+}
+
+// jitteredInterval caps jitter at half of interval, so the wait never drops
+// below interval/2 and a large jitter cannot turn polling into a hot loop.
+func jitteredInterval(random *rand.Rand, interval, jitter time.Duration) time.Duration {
+	jitter = min(jitter, interval/2)
+	if jitter <= 0 {
+		return interval
+	}
+
+	return interval - jitter + time.Duration(random.Int64N(int64(2*jitter)+1))
+}
+
+// startLoading is a no-op while a loading loop is already running. It
+// returns the error of the synchronous initial load.
+func (rcm *RedisConfigManager) startLoading(interval, jitter time.Duration) error {
+	if !rcm.started.CompareAndSwap(false, true) {
+		return nil
+	}
+	l := rcm.restart()
+
 	rcm.wg.Add(1)
+	rcm.polling.Add(1)
 
-	rcm.LoadConfig(rcm.ctx)
+	err := rcm.reload(l)
 
 	go func() {
 		defer rcm.wg.Done()
+		defer rcm.polling.Add(-1)
+
+		rcm.fetchUpdates(l, interval, jitter)
+	}()
 
-		ticker := time.NewTicker(interval)
-		defer ticker.Stop()
+	return err
+}
 
-		rcm.fetchUpdates(ticker)
+// poll starts the loop running fetchUpdates. The loop counts as polling from
+// the moment it is started until it exits.
+func (rcm *RedisConfigManager) poll(l loop, interval, jitter time.Duration) {
+	rcm.wg.Add(1)
+	rcm.polling.Add(1)
+
+	go func() {
+		defer rcm.wg.Done()
+		defer rcm.polling.Add(-1)
+
+		rcm.fetchUpdates(l, interval, jitter)
 	}()
 }
 
-func (rcm *RedisConfigManager) fetchUpdates(ticker *time.Ticker) {
+// fetchUpdates reloads the config until the context is cancelled. The wait
+// before each reload is interval, or the one set under RefreshIntervalKey,
+// spread by jitter.
+func (rcm *RedisConfigManager) fetchUpdates(l loop, interval, jitter time.Duration) {
+	random := rand.New(rand.NewPCG(uint64(time.Now().UnixNano()), rand.Uint64()))
+	timer := time.NewTimer(rcm.nextInterval(random, interval, jitter))
+	defer timer.Stop()
+
 	for {
 		select {
-		case <-rcm.ctx.Done():
+		case <-l.ctx.Done():
 			return
-		case <-ticker.C:
-			rcm.LoadConfig(rcm.ctx)
+		case <-timer.C:
+			rcm.reloadRecovering(l)
+			timer.Reset(rcm.nextInterval(random, interval, jitter))
 		}
 	}
 }
 
+// LoadConfig fetches the service key and any fallback keys and merges them,
+// with the service key taking precedence. Missing fallback keys are skipped.
+// The result is recorded and available through LastError.
 func (rcm *RedisConfigManager) LoadConfig(ctx context.Context) error {
-	rawConfig, err := rcm.r.Get(ctx, rcm.serviceName).Result()
+	return rcm.loadConfig(ctx, rcm.client())
+}
+
+// loadConfig is LoadConfig reading through client, so that a background
+// loop keeps using the client it was started with.
+func (rcm *RedisConfigManager) loadConfig(ctx context.Context, client redis.UniversalClient) error {
+	start := time.Now()
+	err := rcm.loadWithRetry(ctx, client)
+	rcm.recordLoad(start, err)
+	if err == nil {
+		rcm.log().Debugf("loaded config %s in %s", rcm.configKey(), time.Since(start))
+	}
+
+	rcm.mu.Lock()
+	rcm.setLastError(err)
+	rcm.mu.Unlock()
+
+	return err
+}
+
+// Reload refreshes the config immediately, independent of the polling
+// schedule, for example on SIGHUP. It is safe to call while StartLoading or
+// StartWatching is running.
+func (rcm *RedisConfigManager) Reload(ctx context.Context) error {
+	return rcm.LoadConfig(ctx)
+}
+
+func (rcm *RedisConfigManager) load(ctx context.Context, client redis.UniversalClient) error {
+	key := rcm.configKey()
+	version, unchanged, err := rcm.currentVersion(ctx, client, key)
 	if err != nil {
-		return fmt.Errorf("failed to get config: %w\n", err)
+		return err
+	}
+	if unchanged {
+		rcm.markUnchanged()
+		return nil
 	}
 
-	rawConfigMap := make(map[string]any)
-	if err := json.Unmarshal([]byte(rawConfig), &rawConfigMap); err != nil {
-		return fmt.Errorf("failed to unmarshal config: %w\n", err)
+	rawConfigMap, err := rcm.fetchDocument(ctx, client, key)
+	if errors.Is(err, redis.Nil) && rcm.tolerateMissing {
+		rawConfigMap, err = map[string]any{}, nil
+	}
+	if err != nil {
+		return err
 	}
 
-	rcm.mu.Lock()
-	defer rcm.mu.Unlock()
+	rawConfigMap, err = rcm.layerDocument(ctx, client, rawConfigMap)
+	if err != nil {
+		return err
+	}
+
+	return rcm.applyConfig(key, version, rawConfigMap)
+}
+
+// layerDocument merges the fallback documents, the defaults document and the
+// added sources with primary, the document read from the config key, the
+// same way for LoadConfig and Set. Missing fallback keys are skipped. The
+// nested maps of primary may end up shared with the result and modified.
+func (rcm *RedisConfigManager) layerDocument(ctx context.Context, client redis.UniversalClient, primary map[string]any) (map[string]any, error) {
+	if fallbacks := rcm.fallbackKeys(); len(fallbacks) > 0 {
+		merged := make(map[string]any)
+		for i := len(fallbacks) - 1; i >= 0; i-- {
+			fallback, err := rcm.fetchDocument(ctx, client, fallbacks[i])
+			if errors.Is(err, redis.Nil) {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			mergeDocument(merged, fallback)
+		}
+		primary = mergeDocument(merged, primary)
+	}
+
+	return rcm.mergeSources(ctx, primary)
+}
+
+// configKey returns the Redis key holding the config, which defaults to the
+// service name.
+func (rcm *RedisConfigManager) configKey() string {
+	rcm.mu.RLock()
+	defer rcm.mu.RUnlock()
+
+	if rcm.key != "" {
+		return rcm.namespace + rcm.key
+	}
 
-	for key, value := range rawConfigMap {
-		rcm.config[key] = fmt.Sprintf("%v", value)
+	return rcm.namespace + rcm.serviceName
+}
+
+func (rcm *RedisConfigManager) fetchDocument(ctx context.Context, client redis.UniversalClient, key string) (map[string]any, error) {
+	if rcm.hash {
+		return rcm.fetchHash(ctx, client, key)
+	}
+
+	rawConfig, err := client.Get(ctx, key).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get config: %w", err)
+	}
+
+	rawConfigMap, err := rcm.decode([]byte(rawConfig))
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
+	}
+
+	return rawConfigMap, nil
+}
+
+// mergeDocument copies src into dst, recursing into objects present in both,
+// and returns dst. Values from src win.
+func mergeDocument(dst, src map[string]any) map[string]any {
+	for key, value := range src {
+		srcObject, srcIsObject := value.(map[string]any)
+		dstObject, dstIsObject := dst[key].(map[string]any)
+		if srcIsObject && dstIsObject {
+			dst[key] = mergeDocument(dstObject, srcObject)
+			continue
+		}
+		dst[key] = value
 	}
 
-	rcm.updatedAt = time.Now()
+	return dst
+}
+
+// applyConfig builds the config from a document read from key at version,
+// swaps it in and notifies change callbacks. The current config is kept when
+// the validator rejects the new one. A document read from a key that SetKey
+// has since replaced is dropped.
+func (rcm *RedisConfigManager) applyConfig(key, version string, document map[string]any) error {
+	callbacks, changes, err := rcm.swapConfig(key, version, document)
+	if err != nil {
+		return err
+	}
+
+	rcm.notifyChanges(callbacks, changes)
 
 	return nil
 }
 
+// swapConfig does the part of applyConfig that runs under loadMu. The lock
+// is released on the way out even if a validator, decryptor or audit sink
+// panics, so a recovered panic does not block later loads. The version is
+// recorded in the same critical section as the config, so the two always
+// match.
+func (rcm *RedisConfigManager) swapConfig(key, version string, document map[string]any) ([]ChangeFunc, []change, error) {
+	rcm.loadMu.Lock()
+	defer rcm.loadMu.Unlock()
+
+	if key != rcm.configKey() {
+		return nil, nil, nil
+	}
+
+	built, err := rcm.buildConfig(document)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rcm.mu.Lock()
+	changes := diffConfig(rcm.config, built.values)
+	rcm.config = built.values
+	rcm.natives = built.natives
+	rcm.keyErrors = built.keyErrors
+	rcm.lazy = nil
+	rcm.publish()
+	rcm.updatedAt = rcm.now()
+	rcm.trackKeyUpdates(changes, rcm.updatedAt)
+	rcm.lastChanged = changedKeys(changes)
+	rcm.recordVersion(key, version)
+	if len(changes) > 0 {
+		rcm.signalWatchers()
+		rcm.signalKeyWatchers(changes)
+	}
+	callbacks := rcm.onChange
+	updatedAt := rcm.updatedAt
+	rcm.mu.Unlock()
+
+	if rcm.audit != nil && len(changes) > 0 {
+		rcm.audit.RecordLoad(updatedAt, changedKeys(changes))
+	}
+
+	return callbacks, changes, nil
+}
+
+// builtConfig is a config prepared by buildConfig for the swap in
+// applyConfig.
+type builtConfig struct {
+	values    map[string]string
+	natives   map[string]any
+	keyErrors map[string]error
+}
+
+// buildConfig flattens a decoded document into a fresh config map and runs
+// the key checks and the validator on it. It also keeps the decoded value of
+// every key, with decrypted strings replacing their ciphertext. With merge on load, keys missing from the document are
+// carried over from the current config. The current config map is never
+// modified, so readers holding it are unaffected until the swap in
+// applyConfig.
+func (rcm *RedisConfigManager) buildConfig(document map[string]any) (*builtConfig, error) {
+	rcm.mu.RLock()
+	oldConfig := rcm.config
+	oldNatives := rcm.natives
+	validator := rcm.validator
+	rcm.mu.RUnlock()
+
+	document, err := rcm.transformKeys(document)
+	if err != nil {
+		return nil, err
+	}
+
+	newConfig, natives := rcm.foldKeys(conv.FlattenNative(document))
+	if err := rcm.decrypt(newConfig); err != nil {
+		return nil, err
+	}
+	for key, value := range newConfig {
+		if native, ok := natives[key].(string); ok && native != value {
+			natives[key] = value
+		}
+	}
+	if rcm.mergeOnLoad {
+		for key, value := range oldConfig {
+			if _, ok := newConfig[key]; !ok {
+				newConfig[key] = value
+				if native, ok := oldNatives[key]; ok {
+					natives[key] = native
+				}
+			}
+		}
+	}
+	keyErrors := rcm.checkKeys(newConfig)
+
+	if validator != nil {
+		if err := validator(newConfig); err != nil {
+			rcm.log().Warnf("config %s rejected by validator: %v", rcm.configKey(), err)
+			return nil, fmt.Errorf("config rejected by validator: %w", err)
+		}
+	}
+
+	return &builtConfig{values: newConfig, natives: natives, keyErrors: keyErrors}, nil
+}
+
+// LastUpdated returns the time of the last successful LoadConfig.
+// It is zero until the config has been loaded at least once.
+func (rcm *RedisConfigManager) LastUpdated() time.Time {
+	rcm.mu.RLock()
+	defer rcm.mu.RUnlock()
+
+	return rcm.updatedAt
+}
+
+func (rcm *RedisConfigManager) IsLoaded() bool {
+	return !rcm.LastUpdated().IsZero()
+}
+
+// Unmarshal fills the struct v points to from the loaded config. Fields are
+// matched to keys by their cm tag or, without one, by field name. Missing
+// keys leave the field untouched; every field that fails to parse is
+// listed in the returned error.
+func (rcm *RedisConfigManager) Unmarshal(v any) error {
+	return bind.Struct(rcm, rcm.has, v)
+}
+
+func (rcm *RedisConfigManager) has(key string) bool {
+	view := rcm.view()
+
+	_, ok := view.value(key)
+	return ok
+}
+
+// Keys returns a sorted snapshot of all loaded keys.
+func (rcm *RedisConfigManager) Keys() []string {
+	view := rcm.view()
+
+	keys := make([]string, 0, len(view.config))
+	for key := range view.config {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// Snapshot returns a copy of the loaded config that callers may modify.
+func (rcm *RedisConfigManager) Snapshot() map[string]string {
+	view := rcm.view()
+
+	snapshot := make(map[string]string, len(view.config))
+	for key, value := range view.config {
+		snapshot[key] = value
+	}
+
+	return snapshot
+}
+
+// GetByPrefix returns a copy of every loaded key starting with prefix, such
+// as all flags under "feature.checkout.". Registered defaults are not
+// included.
+func (rcm *RedisConfigManager) GetByPrefix(prefix string) map[string]string {
+	view := rcm.view()
+
+	prefix = rcm.foldKey(prefix)
+	matches := make(map[string]string)
+	for key, value := range view.config {
+		if strings.HasPrefix(key, prefix) {
+			matches[key] = value
+		}
+	}
+
+	return matches
+}
+
+// StopLoading stops the background loops and waits for them to exit. The
+// Redis client is closed only if the manager created it; an injected client
+// stays usable by its owner.
 func (rcm *RedisConfigManager) StopLoading() {
+	rcm.stop()
+}
+
+// Close works like StopLoading and returns the error from closing the Redis
+// client, so the manager can be used as an io.Closer. Closing a stopped
+// manager again returns nil.
+func (rcm *RedisConfigManager) Close() error {
+	return rcm.stop()
+}
+
+// ErrStopTimeout is returned by StopLoadingWithTimeout when the background
+// loops do not exit in time.
+var ErrStopTimeout = errors.New("config loader did not stop in time")
+
+// StopLoadingWithTimeout works like StopLoading but waits at most d for the
+// background loops to exit, so a reload stuck on a hung connection cannot
+// block shutdown. Cancelling the context and closing an owned client usually
+// unblock it. If the loops are still running after d, it returns an error
+// wrapping ErrStopTimeout and leaves them to exit on their own. Watch
+// channels are closed, and StartLoading works again, once they have.
+func (rcm *RedisConfigManager) StopLoadingWithTimeout(d time.Duration) error {
+	return rcm.stopWithin(d)
+}
+
+func (rcm *RedisConfigManager) stop() error {
+	return rcm.stopWithin(0)
+}
+
+// stopWithin stops the background loops and waits for them for at most
+// timeout, or without a limit when timeout is not positive.
+func (rcm *RedisConfigManager) stopWithin(timeout time.Duration) error {
+	rcm.mu.Lock()
 	rcm.cancel()
-	rcm.r.Close()
+	var err error
+	if rcm.ownsClient && !rcm.stopped {
+		err = rcm.r.Close()
+	}
+	rcm.stopped = true
+
+	// A stop that timed out is still waiting for the loops; join it rather
+	// than waiting on the WaitGroup twice.
+	done := rcm.stopping
+	if done == nil {
+		done = make(chan struct{})
+		rcm.stopping = done
+		go rcm.finishStop(rcm.run, done)
+	}
+	rcm.mu.Unlock()
+
+	if timeout <= 0 {
+		<-done
+		return err
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case <-done:
+		return err
+	case <-timer.C:
+		return errors.Join(fmt.Errorf("%w: still running after %s", ErrStopTimeout, timeout), err)
+	}
+}
+
+// baseContext returns the context the manager's own context derives from.
+func (rcm *RedisConfigManager) baseContext() context.Context {
+	if rcm.parent == nil {
+		return context.Background()
+	}
+
+	return rcm.parent
+}
+
+// loop is the context and client a background loop was started with. The
+// loop keeps using them even after a restart has replaced the manager's
+// own, so a loop that outlived StopLoadingWithTimeout never reads a context
+// or client that belongs to its successor.
+type loop struct {
+	ctx    context.Context
+	client redis.UniversalClient
+}
+
+// finishStop waits for the loops of run to exit, then closes the watch
+// channels and clears the started flag, unless a newer run has taken them
+// over in the meantime.
+func (rcm *RedisConfigManager) finishStop(run uint64, done chan struct{}) {
+	defer close(done)
+
 	rcm.wg.Wait()
+
+	rcm.mu.Lock()
+	current := rcm.run == run
+	rcm.stopping = nil
+	rcm.mu.Unlock()
+
+	if current {
+		rcm.closeWatchers()
+		rcm.started.Store(false)
+	}
 }
 
-func (rcm *RedisConfigManager) GetInt(key string) (int, error) {
+// restart prepares a stopped manager for another StartLoading or
+// StartWatching: it replaces the cancelled context and reopens the client
+// if StopLoading closed it. It returns the context and client the new loop
+// should use.
+func (rcm *RedisConfigManager) restart() loop {
+	rcm.mu.Lock()
+	defer rcm.mu.Unlock()
+
+	if rcm.stopped {
+		rcm.ctx, rcm.cancel = context.WithCancel(rcm.baseContext())
+		if rcm.ownsClient {
+			rcm.r = rcm.newClient()
+		}
+		rcm.stopped = false
+	}
+	rcm.run++
+
+	return loop{ctx: rcm.ctx, client: rcm.r}
+}
+
+// context returns the manager's context for reloads made outside the
+// background loops.
+func (rcm *RedisConfigManager) context() context.Context {
 	rcm.mu.RLock()
 	defer rcm.mu.RUnlock()
 
-	value, ok := rcm.config[key]
-	if !ok {
-		return 0, fmt.Errorf("key %s not found", key)
+	return rcm.ctx
+}
+
+// client returns the Redis client for calls made outside the background
+// loops.
+func (rcm *RedisConfigManager) client() redis.UniversalClient {
+	rcm.mu.RLock()
+	defer rcm.mu.RUnlock()
+
+	return rcm.r
+}
+
+func (rcm *RedisConfigManager) GetInt(key string) (int, error) {
+	view := rcm.view()
+	if err := rcm.checkNative(view, key, "int"); err != nil {
+		return 0, err
 	}
 
-	return strconv.Atoi(value)
+	value, err := parseCached(view, key, "int", conv.Int)
+	return value, rcm.checkAge(err)
 }
 
 func (rcm *RedisConfigManager) GetFloat(key string) (float64, error) {
-	rcm.mu.RLock()
-	defer rcm.mu.RUnlock()
+	view := rcm.view()
+	if err := rcm.checkNative(view, key, "float"); err != nil {
+		return 0, err
+	}
 
-	value, ok := rcm.config[key]
-	if !ok {
-		return 0, fmt.Errorf("key %s not found", key)
+	parse := conv.Float
+	if rcm.decimalComma {
+		parse = conv.DecimalCommaFloat
 	}
 
-	return strconv.ParseFloat(value, 64)
+	value, err := parseCached(view, key, "float", parse)
+	return value, rcm.checkAge(err)
 }
 
 func (rcm *RedisConfigManager) GetString(key string) (string, error) {
-	rcm.mu.RLock()
-	defer rcm.mu.RUnlock()
+	view := rcm.view()
 
-	value, ok := rcm.config[key]
+	value, ok := view.value(key)
 	if !ok {
-		return "", fmt.Errorf("key %s not found", key)
+		return "", fmt.Errorf("%w: %s", cm.ErrKeyNotFound, key)
+	}
+	if view.composite(key) {
+		return "", fmt.Errorf("key %s is an object or array, not a string: %w", key, cm.ErrTypeMismatch)
+	}
+	if err := rcm.checkNative(view, key, "string"); err != nil {
+		return "", err
 	}
 
-	return value, nil
+	return value, rcm.checkAge(nil)
 }
 
-func (rcm *RedisConfigManager) GetBool(key string) (bool, error) {
-	rcm.mu.RLock()
-	defer rcm.mu.RUnlock()
+// GetRaw returns the stored text of key without checking its kind, so
+// objects and arrays come back as JSON. GetJSON uses it to decode them.
+func (rcm *RedisConfigManager) GetRaw(key string) (string, error) {
+	view := rcm.view()
 
-	value, ok := rcm.config[key]
+	value, ok := view.value(key)
 	if !ok {
-		return false, fmt.Errorf("key %s not found", key)
+		return "", fmt.Errorf("%w: %s", cm.ErrKeyNotFound, key)
+	}
+
+	return value, rcm.checkAge(nil)
+}
+
+func (rcm *RedisConfigManager) GetBool(key string) (bool, error) {
+	view := rcm.view()
+	if err := rcm.checkNative(view, key, "bool"); err != nil {
+		return false, err
 	}
 
-	return strconv.ParseBool(value)
+	value, err := parseCached(view, key, "bool", conv.Bool)
+	return value, rcm.checkAge(err)
 }
 
 func (rcm *RedisConfigManager) GetDuration(key string) (time.Duration, error) {
-	rcm.mu.RLock()
-	defer rcm.mu.RUnlock()
+	view := rcm.view()
+
+	value, err := parseCached(view, key, "duration", conv.Duration)
+	return value, rcm.checkAge(err)
+}
+
+// GetStringSlice parses the value as a JSON array and falls back to splitting
+// it by commas. An empty value yields an empty slice.
+func (rcm *RedisConfigManager) GetStringSlice(key string) ([]string, error) {
+	view := rcm.view()
+
+	value, ok := view.value(key)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", cm.ErrKeyNotFound, key)
+	}
+
+	return conv.StringSlice(value), rcm.checkAge(nil)
+}
+
+// GetBytes decodes the value as standard base64.
+func (rcm *RedisConfigManager) GetBytes(key string) ([]byte, error) {
+	view := rcm.view()
+
+	value, ok := view.value(key)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", cm.ErrKeyNotFound, key)
+	}
+
+	parsed, err := conv.Bytes(key, value)
+	return parsed, rcm.checkAge(err)
+}
+
+func (rcm *RedisConfigManager) GetIntSlice(key string) ([]int, error) {
+	view := rcm.view()
+
+	value, ok := view.value(key)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", cm.ErrKeyNotFound, key)
+	}
+
+	parsed, err := conv.IntSlice(key, value)
+	return parsed, rcm.checkAge(err)
+}
+
+func (rcm *RedisConfigManager) GetFloatSlice(key string) ([]float64, error) {
+	view := rcm.view()
+
+	value, ok := view.value(key)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", cm.ErrKeyNotFound, key)
+	}
+
+	parsed, err := conv.FloatSlice(key, value)
+	return parsed, rcm.checkAge(err)
+}
+
+// GetStringMap parses the value as a JSON object.
+func (rcm *RedisConfigManager) GetStringMap(key string) (map[string]string, error) {
+	view := rcm.view()
+
+	value, ok := view.value(key)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", cm.ErrKeyNotFound, key)
+	}
+
+	parsed, err := conv.StringMap(key, value)
+	return parsed, rcm.checkAge(err)
+}
+
+// GetURL parses the value as a URL and requires it to have a scheme.
+func (rcm *RedisConfigManager) GetURL(key string) (*url.URL, error) {
+	view := rcm.view()
+
+	value, ok := view.value(key)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", cm.ErrKeyNotFound, key)
+	}
 
-	value, ok := rcm.config[key]
+	parsed, err := conv.URL(key, value)
+	return parsed, rcm.checkAge(err)
+}
+
+// GetEnum returns the value if it is one of allowed.
+func (rcm *RedisConfigManager) GetEnum(key string, allowed []string) (string, error) {
+	view := rcm.view()
+
+	value, ok := view.value(key)
+	if !ok {
+		return "", fmt.Errorf("%w: %s", cm.ErrKeyNotFound, key)
+	}
+
+	parsed, err := conv.Enum(key, value, allowed)
+	return parsed, rcm.checkAge(err)
+}
+
+func (rcm *RedisConfigManager) GetIP(key string) (net.IP, error) {
+	view := rcm.view()
+
+	value, ok := view.value(key)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", cm.ErrKeyNotFound, key)
+	}
+
+	parsed, err := conv.IP(key, value)
+	return parsed, rcm.checkAge(err)
+}
+
+func (rcm *RedisConfigManager) GetIPNet(key string) (*net.IPNet, error) {
+	view := rcm.view()
+
+	value, ok := view.value(key)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", cm.ErrKeyNotFound, key)
+	}
+
+	parsed, err := conv.IPNet(key, value)
+	return parsed, rcm.checkAge(err)
+}
+
+func (rcm *RedisConfigManager) GetInt64(key string) (int64, error) {
+	view := rcm.view()
+
+	value, ok := view.value(key)
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", cm.ErrKeyNotFound, key)
+	}
+
+	parsed, err := conv.Int64(key, value)
+	return parsed, rcm.checkAge(err)
+}
+
+func (rcm *RedisConfigManager) GetUint64(key string) (uint64, error) {
+	view := rcm.view()
+
+	value, ok := view.value(key)
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", cm.ErrKeyNotFound, key)
+	}
+
+	parsed, err := conv.Uint64(key, value)
+	return parsed, rcm.checkAge(err)
+}
+
+// GetBytesSize parses sizes such as "512MB" or "1GiB" into a byte count.
+func (rcm *RedisConfigManager) GetBytesSize(key string) (int64, error) {
+	view := rcm.view()
+
+	value, ok := view.value(key)
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", cm.ErrKeyNotFound, key)
+	}
+
+	parsed, err := conv.BytesSize(key, value)
+	return parsed, rcm.checkAge(err)
+}
+
+// GetPercent returns a fraction in [0, 1] from values such as "25%" or 0.25.
+func (rcm *RedisConfigManager) GetPercent(key string) (float64, error) {
+	view := rcm.view()
+
+	value, ok := view.value(key)
 	if !ok {
-		return 0, fmt.Errorf("key %s not found", key)
+		return 0, fmt.Errorf("%w: %s", cm.ErrKeyNotFound, key)
 	}
 
-	return time.ParseDuration(value)
+	parsed, err := conv.Percent(key, value)
+	return parsed, rcm.checkAge(err)
+}
+
+// GetStringMatching returns the value if re matches it, for example to
+// check a hostname or a semantic version where it is read.
+func (rcm *RedisConfigManager) GetStringMatching(key string, re *regexp.Regexp) (string, error) {
+	view := rcm.view()
+
+	value, ok := view.value(key)
+	if !ok {
+		return "", fmt.Errorf("%w: %s", cm.ErrKeyNotFound, key)
+	}
+
+	parsed, err := conv.Matching(key, value, re)
+	return parsed, rcm.checkAge(err)
+}
+
+// GetDurationMillis reads a bare integer as milliseconds, as legacy configs
+// store timeouts, and otherwise parses the value like GetDuration.
+func (rcm *RedisConfigManager) GetDurationMillis(key string) (time.Duration, error) {
+	view := rcm.view()
+
+	value, err := parseCached(view, key, "duration_millis", conv.DurationMillis)
+	return value, rcm.checkAge(err)
+}
+
+// GetColorRGBA parses a hex color such as "#1e90ff", "1e90ff", "#fff" or
+// "#1e90ff80". A translucent color is returned premultiplied by its alpha.
+func (rcm *RedisConfigManager) GetColorRGBA(key string) (color.RGBA, error) {
+	view := rcm.view()
+
+	value, ok := view.value(key)
+	if !ok {
+		return color.RGBA{}, fmt.Errorf("%w: %s", cm.ErrKeyNotFound, key)
+	}
+
+	parsed, err := conv.ColorRGBA(key, value)
+	return parsed, rcm.checkAge(err)
 }
 
 func (rcm *RedisConfigManager) GetIntWithDefault(key string, defaultValue int) int {
 	value, err := rcm.GetInt(key)
-	if err != nil {
+	if err != nil && !errors.Is(err, cm.ErrStale) {
 		return defaultValue
 	}
 
@@ -172,7 +1028,7 @@ func (rcm *RedisConfigManager) GetIntWithDefault(key string, defaultValue int) i
 
 func (rcm *RedisConfigManager) GetFloatWithDefault(key string, defaultValue float64) float64 {
 	value, err := rcm.GetFloat(key)
-	if err != nil {
+	if err != nil && !errors.Is(err, cm.ErrStale) {
 		return defaultValue
 	}
 
@@ -181,7 +1037,7 @@ func (rcm *RedisConfigManager) GetFloatWithDefault(key string, defaultValue floa
 
 func (rcm *RedisConfigManager) GetStringWithDefault(key string, defaultValue string) string {
 	value, err := rcm.GetString(key)
-	if err != nil {
+	if err != nil && !errors.Is(err, cm.ErrStale) {
 		return defaultValue
 	}
 
@@ -190,7 +1046,7 @@ func (rcm *RedisConfigManager) GetStringWithDefault(key string, defaultValue str
 
 func (rcm *RedisConfigManager) GetBoolWithDefault(key string, defaultValue bool) bool {
 	value, err := rcm.GetBool(key)
-	if err != nil {
+	if err != nil && !errors.Is(err, cm.ErrStale) {
 		return defaultValue
 	}
 
@@ -199,7 +1055,151 @@ func (rcm *RedisConfigManager) GetBoolWithDefault(key string, defaultValue bool)
 
 func (rcm *RedisConfigManager) GetDurationWithDefault(key string, defaultValue time.Duration) time.Duration {
 	value, err := rcm.GetDuration(key)
-	if err != nil {
+	if err != nil && !errors.Is(err, cm.ErrStale) {
+		return defaultValue
+	}
+
+	return value
+}
+
+func (rcm *RedisConfigManager) GetStringSliceWithDefault(key string, defaultValue []string) []string {
+	value, err := rcm.GetStringSlice(key)
+	if err != nil && !errors.Is(err, cm.ErrStale) {
+		return defaultValue
+	}
+
+	return value
+}
+
+func (rcm *RedisConfigManager) GetBytesWithDefault(key string, defaultValue []byte) []byte {
+	value, err := rcm.GetBytes(key)
+	if err != nil && !errors.Is(err, cm.ErrStale) {
+		return defaultValue
+	}
+
+	return value
+}
+
+func (rcm *RedisConfigManager) GetIntSliceWithDefault(key string, defaultValue []int) []int {
+	value, err := rcm.GetIntSlice(key)
+	if err != nil && !errors.Is(err, cm.ErrStale) {
+		return defaultValue
+	}
+
+	return value
+}
+
+func (rcm *RedisConfigManager) GetFloatSliceWithDefault(key string, defaultValue []float64) []float64 {
+	value, err := rcm.GetFloatSlice(key)
+	if err != nil && !errors.Is(err, cm.ErrStale) {
+		return defaultValue
+	}
+
+	return value
+}
+
+func (rcm *RedisConfigManager) GetStringMapWithDefault(key string, defaultValue map[string]string) map[string]string {
+	value, err := rcm.GetStringMap(key)
+	if err != nil && !errors.Is(err, cm.ErrStale) {
+		return defaultValue
+	}
+
+	return value
+}
+
+func (rcm *RedisConfigManager) GetURLWithDefault(key string, defaultValue *url.URL) *url.URL {
+	value, err := rcm.GetURL(key)
+	if err != nil && !errors.Is(err, cm.ErrStale) {
+		return defaultValue
+	}
+
+	return value
+}
+
+func (rcm *RedisConfigManager) GetEnumWithDefault(key string, allowed []string, defaultValue string) string {
+	value, err := rcm.GetEnum(key, allowed)
+	if err != nil && !errors.Is(err, cm.ErrStale) {
+		return defaultValue
+	}
+
+	return value
+}
+
+func (rcm *RedisConfigManager) GetIPWithDefault(key string, defaultValue net.IP) net.IP {
+	value, err := rcm.GetIP(key)
+	if err != nil && !errors.Is(err, cm.ErrStale) {
+		return defaultValue
+	}
+
+	return value
+}
+
+func (rcm *RedisConfigManager) GetIPNetWithDefault(key string, defaultValue *net.IPNet) *net.IPNet {
+	value, err := rcm.GetIPNet(key)
+	if err != nil && !errors.Is(err, cm.ErrStale) {
+		return defaultValue
+	}
+
+	return value
+}
+
+func (rcm *RedisConfigManager) GetInt64WithDefault(key string, defaultValue int64) int64 {
+	value, err := rcm.GetInt64(key)
+	if err != nil && !errors.Is(err, cm.ErrStale) {
+		return defaultValue
+	}
+
+	return value
+}
+
+func (rcm *RedisConfigManager) GetUint64WithDefault(key string, defaultValue uint64) uint64 {
+	value, err := rcm.GetUint64(key)
+	if err != nil && !errors.Is(err, cm.ErrStale) {
+		return defaultValue
+	}
+
+	return value
+}
+
+func (rcm *RedisConfigManager) GetBytesSizeWithDefault(key string, defaultValue int64) int64 {
+	value, err := rcm.GetBytesSize(key)
+	if err != nil && !errors.Is(err, cm.ErrStale) {
+		return defaultValue
+	}
+
+	return value
+}
+
+func (rcm *RedisConfigManager) GetPercentWithDefault(key string, defaultValue float64) float64 {
+	value, err := rcm.GetPercent(key)
+	if err != nil && !errors.Is(err, cm.ErrStale) {
+		return defaultValue
+	}
+
+	return value
+}
+
+func (rcm *RedisConfigManager) GetStringMatchingWithDefault(key string, re *regexp.Regexp, defaultValue string) string {
+	value, err := rcm.GetStringMatching(key, re)
+	if err != nil && !errors.Is(err, cm.ErrStale) {
+		return defaultValue
+	}
+
+	return value
+}
+
+func (rcm *RedisConfigManager) GetDurationMillisWithDefault(key string, defaultValue time.Duration) time.Duration {
+	value, err := rcm.GetDurationMillis(key)
+	if err != nil && !errors.Is(err, cm.ErrStale) {
+		return defaultValue
+	}
+
+	return value
+}
+
+func (rcm *RedisConfigManager) GetColorRGBAWithDefault(key string, defaultValue color.RGBA) color.RGBA {
+	value, err := rcm.GetColorRGBA(key)
+	if err != nil && !errors.Is(err, cm.ErrStale) {
 		return defaultValue
 	}
 
@@ -3,11 +3,24 @@ package rcm
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"image/color"
+	"io"
+	"math"
+	"math/rand/v2"
+	"net"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/alicebob/miniredis/v2"
 	"github.com/redis/go-redis/v9"
+	"github.com/zemld/config-manager/pkg/cm"
 )
 
 func setupTestRedis(t *testing.T) (*miniredis.Miniredis, *redis.Client) {
@@ -627,6 +640,65 @@ func TestConcurrentAccess(t *testing.T) {
 		}
 	}
 
+	for _, cached := range []bool{false, true} {
+		rcm := &RedisConfigManager{
+			serviceName: serviceName,
+			config:      make(map[string]string),
+			r:           client,
+			ctx:         context.Background(),
+			cacheParsed: cached,
+		}
+
+		if err := rcm.LoadConfig(context.Background()); err != nil {
+			t.Fatalf("LoadConfig failed: %v", err)
+		}
+
+		done := make(chan bool)
+		concurrency := 10
+
+		for i := 0; i < concurrency; i++ {
+			go func() {
+				defer func() { done <- true }()
+				for j := 0; j < 100; j++ {
+					_, _ = rcm.GetString("string_key")
+					_, _ = rcm.GetInt("int_key")
+					_, _ = rcm.GetFloat("float_key")
+				}
+			}()
+		}
+
+		go func() {
+			defer func() { done <- true }()
+			for j := 0; j < 10; j++ {
+				_ = rcm.LoadConfig(context.Background())
+			}
+		}()
+
+		for i := 0; i < concurrency+1; i++ {
+			<-done
+		}
+	}
+}
+
+func TestGetStringSlice(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	serviceName := "test_service"
+	configJSON, err := json.Marshal(map[string]interface{}{
+		"json_string_key": `["a", "b", "c"]`,
+		"json_array_key":  []string{"x", "y"},
+		"comma_key":       "a, b ,c",
+		"empty_key":       "",
+	})
+	if err != nil {
+		t.Fatalf("failed to marshal config: %v", err)
+	}
+	if err := mr.Set(serviceName, string(configJSON)); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+
 	rcm := &RedisConfigManager{
 		serviceName: serviceName,
 		config:      make(map[string]string),
@@ -638,21 +710,1495 @@ func TestConcurrentAccess(t *testing.T) {
 		t.Fatalf("LoadConfig failed: %v", err)
 	}
 
-	done := make(chan bool)
-	concurrency := 10
+	tests := map[string][]string{
+		"json_string_key": {"a", "b", "c"},
+		"json_array_key":  {"x", "y"},
+		"comma_key":       {"a", "b", "c"},
+		"empty_key":       {},
+	}
 
-	for i := 0; i < concurrency; i++ {
-		go func() {
-			defer func() { done <- true }()
-			for j := 0; j < 100; j++ {
-				_, _ = rcm.GetString("string_key")
-				_, _ = rcm.GetInt("int_key")
-				_, _ = rcm.GetFloat("float_key")
-			}
-		}()
+	for key, expected := range tests {
+		value, err := rcm.GetStringSlice(key)
+		if err != nil {
+			t.Fatalf("GetStringSlice(%s) failed: %v", key, err)
+		}
+		if !reflect.DeepEqual(value, expected) {
+			t.Errorf("GetStringSlice(%s): expected %v, got %v", key, expected, value)
+		}
+	}
+
+	_, err = rcm.GetStringSlice("nonexistent_key")
+	if err == nil {
+		t.Error("expected error for nonexistent key")
+	}
+
+	defaultValue := rcm.GetStringSliceWithDefault("nonexistent_key", []string{"default"})
+	if !reflect.DeepEqual(defaultValue, []string{"default"}) {
+		t.Errorf("expected default value [default], got %v", defaultValue)
+	}
+}
+
+func TestLastUpdated(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	serviceName := "test_service"
+	config := createTestConfig(t, serviceName)
+
+	for key, value := range config {
+		if err := mr.Set(key, value.(string)); err != nil {
+			t.Fatalf("failed to set config in miniredis: %v", err)
+		}
+	}
+
+	rcm := &RedisConfigManager{
+		serviceName: serviceName,
+		config:      make(map[string]string),
+		r:           client,
+		ctx:         context.Background(),
+	}
+
+	if !rcm.LastUpdated().IsZero() {
+		t.Error("expected zero LastUpdated before loading")
+	}
+
+	before := time.Now()
+	if err := rcm.LoadConfig(context.Background()); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	if rcm.LastUpdated().Before(before) {
+		t.Errorf("expected LastUpdated after %v, got %v", before, rcm.LastUpdated())
+	}
+}
+
+func TestGetInt_FloatFormatted(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	serviceName := "test_service"
+	if err := mr.Set(serviceName, `{"million": 1000000, "exponent": 1e6, "whole": 42.0, "fraction": 42.5}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+
+	rcm := &RedisConfigManager{
+		serviceName: serviceName,
+		config:      make(map[string]string),
+		r:           client,
+		ctx:         context.Background(),
+	}
+
+	if err := rcm.LoadConfig(context.Background()); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	expected := map[string]int{"million": 1000000, "exponent": 1000000, "whole": 42}
+	for key, want := range expected {
+		value, err := rcm.GetInt(key)
+		if err != nil {
+			t.Errorf("GetInt(%s) failed: %v", key, err)
+			continue
+		}
+		if value != want {
+			t.Errorf("GetInt(%s): expected %d, got %d", key, want, value)
+		}
+	}
+
+	if _, err := rcm.GetInt("fraction"); err == nil {
+		t.Error("expected error for a value with a fractional part")
+	}
+}
+
+func TestLoadConfig_PreservesNumberPrecision(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	serviceName := "test_service"
+	if err := mr.Set(serviceName, `{"id": 1234567890123456789, "small": 0.0000001}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+
+	rcm := &RedisConfigManager{
+		serviceName: serviceName,
+		config:      make(map[string]string),
+		r:           client,
+		ctx:         context.Background(),
+	}
+
+	if err := rcm.LoadConfig(context.Background()); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	id, err := rcm.GetString("id")
+	if err != nil {
+		t.Fatalf("GetString failed: %v", err)
+	}
+	if id != "1234567890123456789" {
+		t.Errorf("expected '1234567890123456789', got '%s'", id)
+	}
+
+	if strconv.IntSize == 64 {
+		value, err := rcm.GetInt("id")
+		if err != nil {
+			t.Fatalf("GetInt failed: %v", err)
+		}
+		if value != 1234567890123456789 {
+			t.Errorf("expected 1234567890123456789, got %d", value)
+		}
+	}
+
+	small, err := rcm.GetString("small")
+	if err != nil {
+		t.Fatalf("GetString failed: %v", err)
+	}
+	if small != "0.0000001" {
+		t.Errorf("expected '0.0000001', got '%s'", small)
+	}
+}
+
+func TestGetBytes(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	serviceName := "test_service"
+	if err := mr.Set(serviceName, `{"key_bytes": "c2lnbmluZy1rZXk=", "invalid_bytes": "not base64!"}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+
+	rcm := &RedisConfigManager{
+		serviceName: serviceName,
+		config:      make(map[string]string),
+		r:           client,
+		ctx:         context.Background(),
+	}
+
+	if err := rcm.LoadConfig(context.Background()); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	value, err := rcm.GetBytes("key_bytes")
+	if err != nil {
+		t.Fatalf("GetBytes failed: %v", err)
+	}
+	if string(value) != "signing-key" {
+		t.Errorf("expected 'signing-key', got '%s'", value)
+	}
+
+	_, err = rcm.GetBytes("invalid_bytes")
+	if err == nil || !strings.Contains(err.Error(), "invalid_bytes") {
+		t.Errorf("expected error naming the key, got %v", err)
+	}
+
+	defaultValue := rcm.GetBytesWithDefault("nonexistent_key", []byte("default"))
+	if string(defaultValue) != "default" {
+		t.Errorf("expected default value 'default', got '%s'", defaultValue)
+	}
+}
+
+func TestLoadConfig_NestedKeys(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	serviceName := "test_service"
+	if err := mr.Set(serviceName, `{"db": {"host": "x", "port": 5432}, "list": ["a", "b"]}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+
+	rcm := &RedisConfigManager{
+		serviceName: serviceName,
+		config:      make(map[string]string),
+		r:           client,
+		ctx:         context.Background(),
+	}
+
+	if err := rcm.LoadConfig(context.Background()); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	host, err := rcm.GetString("db.host")
+	if err != nil || host != "x" {
+		t.Errorf("expected db.host 'x', got '%s' (%v)", host, err)
+	}
+
+	port, err := rcm.GetInt("db.port")
+	if err != nil || port != 5432 {
+		t.Errorf("expected db.port 5432, got %d (%v)", port, err)
+	}
+
+	item, err := rcm.GetString("list.1")
+	if err != nil || item != "b" {
+		t.Errorf("expected list.1 'b', got '%s' (%v)", item, err)
+	}
+
+	list, err := rcm.GetStringSlice("list")
+	if err != nil || !reflect.DeepEqual(list, []string{"a", "b"}) {
+		t.Errorf("expected list [a b], got %v (%v)", list, err)
+	}
+}
+
+func TestUnmarshal(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	serviceName := "test_service"
+	config := createTestConfig(t, serviceName)
+
+	for key, value := range config {
+		if err := mr.Set(key, value.(string)); err != nil {
+			t.Fatalf("failed to set config in miniredis: %v", err)
+		}
+	}
+
+	rcm := &RedisConfigManager{
+		serviceName: serviceName,
+		config:      make(map[string]string),
+		r:           client,
+		ctx:         context.Background(),
+	}
+
+	if err := rcm.LoadConfig(context.Background()); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	var target struct {
+		Int      int           `cm:"int_key"`
+		Float    float64       `cm:"float_key"`
+		String   string        `cm:"string_key"`
+		Bool     bool          `cm:"bool_key"`
+		Duration time.Duration `cm:"duration_key"`
+		Missing  string        `cm:"nonexistent_key"`
+		Skipped  string        `cm:"-"`
+	}
+	target.Missing = "unchanged"
+
+	if err := rcm.Unmarshal(&target); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+
+	if target.Int != 42 || target.Float != 3.14 || target.String != "test_value" || !target.Bool || target.Duration != 5*time.Second {
+		t.Errorf("unexpected unmarshal result: %+v", target)
+	}
+	if target.Missing != "unchanged" {
+		t.Errorf("expected missing key to leave field untouched, got '%s'", target.Missing)
+	}
+}
+
+func TestUnmarshal_AggregatesErrors(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	serviceName := "test_service"
+	if err := mr.Set(serviceName, `{"Port": "abc", "debug": "maybe", "Name": "svc"}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+
+	rcm := &RedisConfigManager{
+		serviceName: serviceName,
+		config:      make(map[string]string),
+		r:           client,
+		ctx:         context.Background(),
+	}
+
+	if err := rcm.LoadConfig(context.Background()); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	var target struct {
+		Port  int
+		Debug bool `cm:"debug"`
+		Name  string
+	}
+
+	err := rcm.Unmarshal(&target)
+	if err == nil {
+		t.Fatal("expected error for malformed fields")
+	}
+	for _, field := range []string{"Port", "Debug"} {
+		if !strings.Contains(err.Error(), field) {
+			t.Errorf("expected error to mention field %s, got %v", field, err)
+		}
+	}
+	if target.Name != "svc" {
+		t.Errorf("expected valid fields to be set, got Name '%s'", target.Name)
+	}
+
+	if err := rcm.Unmarshal(target); err == nil {
+		t.Error("expected error for a non-pointer target")
+	}
+}
+
+func TestWithFallbacks(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	documents := map[string]string{
+		"service": `{"timeout": "1s", "db": {"port": 6543}}`,
+		"team":    `{"timeout": "2s", "retries": 3}`,
+		"shared":  `{"timeout": "3s", "retries": 5, "region": "eu", "db": {"host": "shared-db", "port": 5432}}`,
+	}
+	for key, value := range documents {
+		if err := mr.Set(key, value); err != nil {
+			t.Fatalf("failed to set config in miniredis: %v", err)
+		}
+	}
+
+	rcm := NewRedisConfigManager("service", &redis.Options{
+		Addr: mr.Addr(),
+	}, WithFallbacks("team", "missing", "shared"))
+	defer rcm.StopLoading()
+
+	if err := rcm.LoadConfig(context.Background()); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	expected := map[string]string{
+		"timeout": "1s",
+		"retries": "3",
+		"region":  "eu",
+		"db.host": "shared-db",
+		"db.port": "6543",
+	}
+	for key, want := range expected {
+		value, err := rcm.GetString(key)
+		if err != nil {
+			t.Errorf("GetString(%s) failed: %v", key, err)
+			continue
+		}
+		if value != want {
+			t.Errorf("GetString(%s): expected '%s', got '%s'", key, want, value)
+		}
+	}
+
+	if _, err := rcm.GetString("nonexistent_key"); err == nil {
+		t.Error("expected error for a key missing from every layer")
+	}
+}
+
+func TestGetters_ErrKeyNotFound(t *testing.T) {
+	rcm := &RedisConfigManager{
+		serviceName: "test_service",
+		config:      map[string]string{"int_key": "not a number"},
+	}
+
+	if _, err := rcm.GetInt("nonexistent_key"); !errors.Is(err, cm.ErrKeyNotFound) {
+		t.Errorf("expected ErrKeyNotFound, got %v", err)
+	}
+
+	_, err := rcm.GetInt("int_key")
+	if err == nil {
+		t.Fatal("expected parse error")
+	}
+	if errors.Is(err, cm.ErrKeyNotFound) {
+		t.Error("parse error must not be reported as ErrKeyNotFound")
+	}
+}
+
+func TestGetStringContext(t *testing.T) {
+	var _ cm.ContextConfigGetter = (*RedisConfigManager)(nil)
+
+	rcm := &RedisConfigManager{
+		serviceName: "test_service",
+		config:      map[string]string{"string_key": "test_value"},
+	}
+
+	value, err := rcm.GetStringContext(context.Background(), "string_key")
+	if err != nil || value != "test_value" {
+		t.Errorf("expected 'test_value', got '%s' (%v)", value, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := rcm.GetStringContext(ctx, "string_key"); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestKeys(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	serviceName := "test_service"
+	config := createTestConfig(t, serviceName)
+
+	for key, value := range config {
+		if err := mr.Set(key, value.(string)); err != nil {
+			t.Fatalf("failed to set config in miniredis: %v", err)
+		}
+	}
+
+	rcm := &RedisConfigManager{
+		serviceName: serviceName,
+		config:      make(map[string]string),
+		r:           client,
+		ctx:         context.Background(),
+	}
+
+	if keys := rcm.Keys(); len(keys) != 0 {
+		t.Errorf("expected no keys before loading, got %v", keys)
+	}
+
+	if err := rcm.LoadConfig(context.Background()); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	expected := []string{"bool_key", "duration_key", "float_key", "int_key", "string_key"}
+	if keys := rcm.Keys(); !reflect.DeepEqual(keys, expected) {
+		t.Errorf("expected keys %v, got %v", expected, keys)
+	}
+}
+
+func TestSnapshot(t *testing.T) {
+	rcm := &RedisConfigManager{
+		serviceName: "test_service",
+		config:      map[string]string{"string_key": "test_value"},
+	}
+
+	snapshot := rcm.Snapshot()
+	if !reflect.DeepEqual(snapshot, map[string]string{"string_key": "test_value"}) {
+		t.Errorf("unexpected snapshot: %v", snapshot)
+	}
+
+	snapshot["string_key"] = "mutated"
+	snapshot["new_key"] = "added"
+
+	if value, _ := rcm.GetString("string_key"); value != "test_value" {
+		t.Errorf("mutating the snapshot changed the config: got '%s'", value)
+	}
+	if _, err := rcm.GetString("new_key"); err == nil {
+		t.Error("adding to the snapshot changed the config")
+	}
+}
+
+func TestLoadConfig_ErrorsCompose(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	rcm := &RedisConfigManager{
+		serviceName: "test_service",
+		config:      make(map[string]string),
+		r:           client,
+		ctx:         context.Background(),
+	}
+
+	err := rcm.LoadConfig(context.Background())
+	if !errors.Is(err, redis.Nil) {
+		t.Errorf("expected error wrapping redis.Nil, got %v", err)
+	}
+	if err != nil && strings.TrimSpace(err.Error()) != err.Error() {
+		t.Errorf("error contains stray whitespace: %q", err.Error())
+	}
+}
+
+func TestJitteredInterval(t *testing.T) {
+	random := rand.New(rand.NewPCG(1, 2))
+	interval := 10 * time.Second
+	jitter := 2 * time.Second
+
+	seen := make(map[time.Duration]bool)
+	for i := 0; i < 1000; i++ {
+		value := jitteredInterval(random, interval, jitter)
+		if value < interval-jitter || value > interval+jitter {
+			t.Fatalf("interval %v outside [%v, %v]", value, interval-jitter, interval+jitter)
+		}
+		seen[value] = true
+	}
+	if len(seen) < 2 {
+		t.Error("expected jittered intervals to vary")
+	}
+
+	if value := jitteredInterval(random, interval, 0); value != interval {
+		t.Errorf("expected %v without jitter, got %v", interval, value)
+	}
+
+	for i := 0; i < 100; i++ {
+		if value := jitteredInterval(random, time.Second, time.Hour); value < 0 || value > 2*time.Second {
+			t.Fatalf("expected jitter to be capped at the interval, got %v", value)
+		}
+	}
+}
+
+func TestJitteredInterval_JitterAtLeastInterval(t *testing.T) {
+	random := rand.New(rand.NewPCG(1, 2))
+	interval := time.Second
+
+	for _, jitter := range []time.Duration{interval, 2 * interval, time.Hour} {
+		for i := 0; i < 1000; i++ {
+			value := jitteredInterval(random, interval, jitter)
+			if value < interval/2 || value > interval+interval/2 {
+				t.Fatalf("jitter %v: interval %v outside [%v, %v]", jitter, value, interval/2, interval+interval/2)
+			}
+		}
+	}
+}
+
+func TestStartLoadingWithJitter(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	serviceName := "test_service"
+	if err := mr.Set(serviceName, `{"string_key": "old"}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+
+	rcm := &RedisConfigManager{
+		serviceName: serviceName,
+		config:      make(map[string]string),
+		r:           client,
+	}
+	rcm.ctx, rcm.cancel = context.WithCancel(context.Background())
+
+	rcm.StartLoadingWithJitter(20*time.Millisecond, 10*time.Millisecond)
+	defer rcm.StopLoading()
+
+	if err := mr.Set(serviceName, `{"string_key": "new"}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if value, _ := rcm.GetString("string_key"); value == "new" {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	t.Error("config was not reloaded by the jittered poller")
+}
+
+func TestGetIntSliceAndFloatSlice(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	serviceName := "test_service"
+	if err := mr.Set(serviceName, `{"backoff": [1, 2, 4, 8], "ratios": "0.5, 1.5", "mixed": [1, "two", 3]}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+
+	rcm := &RedisConfigManager{
+		serviceName: serviceName,
+		config:      make(map[string]string),
+		r:           client,
+		ctx:         context.Background(),
+	}
+
+	if err := rcm.LoadConfig(context.Background()); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	ints, err := rcm.GetIntSlice("backoff")
+	if err != nil || !reflect.DeepEqual(ints, []int{1, 2, 4, 8}) {
+		t.Errorf("expected [1 2 4 8], got %v (%v)", ints, err)
+	}
+
+	floats, err := rcm.GetFloatSlice("ratios")
+	if err != nil || !reflect.DeepEqual(floats, []float64{0.5, 1.5}) {
+		t.Errorf("expected [0.5 1.5], got %v (%v)", floats, err)
+	}
+
+	_, err = rcm.GetIntSlice("mixed")
+	if err == nil || !strings.Contains(err.Error(), "element 1") {
+		t.Errorf("expected error identifying element 1, got %v", err)
+	}
+
+	defaultValue := rcm.GetFloatSliceWithDefault("nonexistent_key", []float64{1})
+	if !reflect.DeepEqual(defaultValue, []float64{1}) {
+		t.Errorf("expected default value [1], got %v", defaultValue)
+	}
+}
+
+func TestGetStringMap(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	serviceName := "test_service"
+	if err := mr.Set(serviceName, `{"headers": {"X-A": "1", "X-B": 2}, "string_key": "value"}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+
+	rcm := &RedisConfigManager{
+		serviceName: serviceName,
+		config:      make(map[string]string),
+		r:           client,
+		ctx:         context.Background(),
+	}
+
+	if err := rcm.LoadConfig(context.Background()); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	headers, err := rcm.GetStringMap("headers")
+	expected := map[string]string{"X-A": "1", "X-B": "2"}
+	if err != nil || !reflect.DeepEqual(headers, expected) {
+		t.Errorf("expected %v, got %v (%v)", expected, headers, err)
+	}
+
+	if _, err := rcm.GetStringMap("string_key"); err == nil {
+		t.Error("expected error for a value that is not an object")
+	}
+
+	if _, err := rcm.GetStringMap("nonexistent_key"); !errors.Is(err, cm.ErrKeyNotFound) {
+		t.Errorf("expected ErrKeyNotFound, got %v", err)
+	}
+}
+
+func TestLoadConfig_RemovesDeletedKeys(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	serviceName := "test_service"
+	if err := mr.Set(serviceName, `{"kept_key": "1", "removed_key": "2"}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+
+	rcm := &RedisConfigManager{
+		serviceName: serviceName,
+		config:      make(map[string]string),
+		r:           client,
+		ctx:         context.Background(),
+	}
+
+	if err := rcm.LoadConfig(context.Background()); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if _, err := rcm.GetString("removed_key"); err != nil {
+		t.Fatalf("expected removed_key after first load: %v", err)
+	}
+
+	if err := mr.Set(serviceName, `{"kept_key": "1"}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+	if err := rcm.LoadConfig(context.Background()); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	if _, err := rcm.GetString("removed_key"); !errors.Is(err, cm.ErrKeyNotFound) {
+		t.Errorf("expected ErrKeyNotFound for a deleted key, got %v", err)
+	}
+	if value, err := rcm.GetString("kept_key"); err != nil || value != "1" {
+		t.Errorf("expected kept_key '1', got '%s' (%v)", value, err)
+	}
+}
+
+func TestGetURL(t *testing.T) {
+	rcm := &RedisConfigManager{
+		serviceName: "test_service",
+		config: map[string]string{
+			"absolute_url": "https://api.example.com:8443/v1?x=1",
+			"relative_url": "/v1/users",
+			"bad_url":      "http://[::1",
+		},
+	}
+
+	value, err := rcm.GetURL("absolute_url")
+	if err != nil {
+		t.Fatalf("GetURL failed: %v", err)
+	}
+	if value.Scheme != "https" || value.Host != "api.example.com:8443" || value.Path != "/v1" {
+		t.Errorf("unexpected url: %v", value)
+	}
+
+	if _, err := rcm.GetURL("relative_url"); err == nil || !strings.Contains(err.Error(), "relative_url") {
+		t.Errorf("expected error naming the key for a relative url, got %v", err)
+	}
+
+	if _, err := rcm.GetURL("bad_url"); err == nil {
+		t.Error("expected error for a malformed url")
+	}
+
+	fallback, _ := url.Parse("http://localhost")
+	if value := rcm.GetURLWithDefault("bad_url", fallback); value != fallback {
+		t.Errorf("expected default url, got %v", value)
+	}
+}
+
+func TestGetEnum(t *testing.T) {
+	rcm := &RedisConfigManager{
+		serviceName: "test_service",
+		config:      map[string]string{"log_level": "warn", "bad_level": "verbose"},
+	}
+	levels := []string{"debug", "info", "warn", "error"}
+
+	value, err := rcm.GetEnum("log_level", levels)
+	if err != nil || value != "warn" {
+		t.Errorf("expected 'warn', got '%s' (%v)", value, err)
+	}
+
+	_, err = rcm.GetEnum("bad_level", levels)
+	if err == nil {
+		t.Fatal("expected error for a value outside the allowed set")
+	}
+	for _, part := range []string{"bad_level", "verbose", "debug, info, warn, error"} {
+		if !strings.Contains(err.Error(), part) {
+			t.Errorf("expected error to contain %q, got %v", part, err)
+		}
+	}
+
+	if value := rcm.GetEnumWithDefault("bad_level", levels, "info"); value != "info" {
+		t.Errorf("expected default 'info' for an invalid value, got '%s'", value)
+	}
+	if value := rcm.GetEnumWithDefault("nonexistent_key", levels, "info"); value != "info" {
+		t.Errorf("expected default 'info' for a missing key, got '%s'", value)
+	}
+}
+
+func TestStopLoadingKeepsInjectedClient(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	serviceName := "test_service"
+	if err := mr.Set(serviceName, `{"string_key": "value"}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+
+	rcm := &RedisConfigManager{
+		serviceName: serviceName,
+		config:      make(map[string]string),
+		r:           client,
+	}
+	rcm.ctx, rcm.cancel = context.WithCancel(context.Background())
+
+	rcm.StartLoading(20 * time.Millisecond)
+	rcm.StopLoading()
+
+	if err := client.Ping(context.Background()).Err(); err != nil {
+		t.Errorf("expected injected client to stay usable after StopLoading, got %v", err)
+	}
+}
+
+func TestNewRedisConfigManagerWithClient(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	if err := mr.Set("config_key", `{"port": 8080}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+
+	manager := NewRedisConfigManagerWithClient("test_service", client, WithKey("config_key"))
+	if err := manager.LoadConfig(context.Background()); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	port, err := manager.GetInt("port")
+	if err != nil || port != 8080 {
+		t.Errorf("expected port 8080, got %d (err: %v)", port, err)
+	}
+
+	manager.StopLoading()
+
+	if err := client.Ping(context.Background()).Err(); err != nil {
+		t.Errorf("expected injected client to stay open after StopLoading, got %v", err)
+	}
+}
+
+func TestStartLoadingTwice(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	serviceName := "test_service"
+	if err := mr.Set(serviceName, `{"string_key": "value"}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+
+	metrics := &fakeMetrics{}
+	rcm := &RedisConfigManager{
+		serviceName: serviceName,
+		config:      make(map[string]string),
+		r:           client,
+		metrics:     metrics,
+	}
+	rcm.ctx, rcm.cancel = context.WithCancel(context.Background())
+
+	rcm.StartLoading(time.Hour)
+	rcm.StartLoading(time.Hour)
+	defer rcm.StopLoading()
+
+	metrics.mu.Lock()
+	loads := metrics.successes
+	metrics.mu.Unlock()
+
+	if loads != 1 {
+		t.Errorf("expected the second StartLoading to be a no-op, got %d loads", loads)
+	}
+}
+
+func TestStartLoadingAfterStop(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	serviceName := "test_service"
+	if err := mr.Set(serviceName, `{"string_key": "first"}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		manager func() *RedisConfigManager
+	}{
+		{
+			name: "injected client",
+			manager: func() *RedisConfigManager {
+				return NewRedisConfigManagerWithClient(serviceName, client).(*RedisConfigManager)
+			},
+		},
+		{
+			name: "owned client",
+			manager: func() *RedisConfigManager {
+				return NewRedisConfigManager(serviceName, &redis.Options{Addr: mr.Addr()}).(*RedisConfigManager)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := mr.Set(serviceName, `{"string_key": "first"}`); err != nil {
+				t.Fatalf("failed to set config in miniredis: %v", err)
+			}
+
+			rcm := tt.manager()
+			rcm.StartLoading(20 * time.Millisecond)
+			rcm.StopLoading()
+
+			rcm.StartLoading(20 * time.Millisecond)
+			defer rcm.StopLoading()
+
+			if err := mr.Set(serviceName, `{"string_key": "second"}`); err != nil {
+				t.Fatalf("failed to set config in miniredis: %v", err)
+			}
+
+			deadline := time.Now().Add(time.Second)
+			for {
+				value, _ := rcm.GetString("string_key")
+				if value == "second" {
+					break
+				}
+				if time.Now().After(deadline) {
+					t.Fatalf("expected restarted manager to pick up the new value, got %q", value)
+				}
+				time.Sleep(10 * time.Millisecond)
+			}
+		})
+	}
+}
+
+func TestStartLoadingWithInitialLoad(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	serviceName := "test_service"
+
+	missing := NewRedisConfigManagerWithClient(serviceName, client).(*RedisConfigManager)
+	if err := missing.StartLoadingWithInitialLoad(time.Hour); err == nil {
+		t.Error("expected an error for a missing config key")
+	}
+	missing.StopLoading()
+
+	if err := mr.Set(serviceName, `{"string_key": "value"}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+
+	rcm := NewRedisConfigManagerWithClient(serviceName, client).(*RedisConfigManager)
+	if err := rcm.StartLoadingWithInitialLoad(time.Hour); err != nil {
+		t.Fatalf("StartLoadingWithInitialLoad failed: %v", err)
+	}
+	defer rcm.StopLoading()
+
+	if value, err := rcm.GetString("string_key"); err != nil || value != "value" {
+		t.Errorf("expected config to be loaded before returning, got %q (err: %v)", value, err)
+	}
+}
+
+func TestStartLoadingStrict(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	serviceName := "test_service"
+
+	rcm := NewRedisConfigManagerWithClient(serviceName, client).(*RedisConfigManager)
+	if err := rcm.StartLoadingStrict(context.Background(), 10*time.Millisecond); err == nil {
+		t.Fatal("expected an error for a missing config key")
+	}
+	if rcm.started.Load() {
+		t.Error("expected polling not to start after a failed initial load")
+	}
+
+	if err := mr.Set(serviceName, `{"string_key": "first"}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+	if err := rcm.StartLoadingStrict(context.Background(), 10*time.Millisecond); err != nil {
+		t.Fatalf("StartLoadingStrict failed: %v", err)
+	}
+	defer rcm.StopLoading()
+
+	if value, err := rcm.GetString("string_key"); err != nil || value != "first" {
+		t.Errorf("expected config to be loaded before returning, got %q (err: %v)", value, err)
+	}
+
+	if err := mr.Set(serviceName, `{"string_key": "second"}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+	deadline := time.Now().Add(time.Second)
+	for {
+		if value, _ := rcm.GetString("string_key"); value == "second" {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("expected polling to pick up the new config")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestStopLoadingWithTimeout(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	serviceName := "test_service"
+	if err := mr.Set(serviceName, `{"string_key": "value"}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+
+	rcm := NewRedisConfigManagerWithClient(serviceName, client).(*RedisConfigManager)
+	rcm.StartLoading(10 * time.Millisecond)
+	if err := rcm.StopLoadingWithTimeout(time.Second); err != nil {
+		t.Errorf("expected a clean stop, got %v", err)
+	}
+
+	var calls atomic.Int32
+	stuck := make(chan struct{})
+	release := make(chan struct{})
+	hung := NewRedisConfigManagerWithClient(serviceName, client).(*RedisConfigManager)
+	hung.SetValidator(func(map[string]string) error {
+		if calls.Add(1) == 2 {
+			close(stuck)
+			<-release
+		}
+		return nil
+	})
+	hung.StartLoading(10 * time.Millisecond)
+	changes := hung.Watch()
+	<-stuck
+
+	if err := hung.StopLoadingWithTimeout(20 * time.Millisecond); !errors.Is(err, ErrStopTimeout) {
+		t.Errorf("expected ErrStopTimeout for a hung reload, got %v", err)
+	}
+
+	close(release)
+	select {
+	case _, ok := <-changes:
+		if ok {
+			t.Error("expected the watch channel to be closed, got a notification")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected the watch channel to be closed once the hung loop exits")
+	}
+	for hung.started.Load() {
+		time.Sleep(time.Millisecond)
+	}
+
+	hung.SetValidator(nil)
+	if err := mr.Set(serviceName, `{"string_key": "restarted"}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+	hung.StartLoading(time.Hour)
+	defer hung.StopLoading()
+	if value, err := hung.GetString("string_key"); err != nil || value != "restarted" {
+		t.Errorf("expected StartLoading to work after a timed-out stop, got %q (err: %v)", value, err)
+	}
+}
+
+func TestReload(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	serviceName := "test_service"
+	if err := mr.Set(serviceName, `{"string_key": "first"}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+
+	rcm := NewRedisConfigManagerWithClient(serviceName, client).(*RedisConfigManager)
+	rcm.StartLoading(time.Hour)
+	defer rcm.StopLoading()
+
+	if err := mr.Set(serviceName, `{"string_key": "second"}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+	if err := rcm.Reload(context.Background()); err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+
+	if value, _ := rcm.GetString("string_key"); value != "second" {
+		t.Errorf("expected Reload to pick up the new value, got %q", value)
+	}
+}
+
+func TestParsedCacheInvalidatedOnLoad(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	serviceName := "test_service"
+	if err := mr.Set(serviceName, `{"port": 8080}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+
+	rcm := NewRedisConfigManagerWithClient(serviceName, client, WithParsedCache(true)).(*RedisConfigManager)
+	if err := rcm.LoadConfig(context.Background()); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if port, err := rcm.GetInt("port"); err != nil || port != 8080 {
+			t.Fatalf("expected 8080, got %d (err: %v)", port, err)
+		}
+	}
+	if _, ok := rcm.parsed.Load(cacheKey{key: "port", kind: "int"}); !ok {
+		t.Error("expected the parsed value to be cached")
+	}
+
+	if err := mr.Set(serviceName, `{"port": 9090}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+	if err := rcm.LoadConfig(context.Background()); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	if port, err := rcm.GetInt("port"); err != nil || port != 9090 {
+		t.Errorf("expected the cache to be dropped on load, got %d (err: %v)", port, err)
+	}
+}
+
+func TestNewRedisUniversalConfigManager(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	serviceName := "test_service"
+	if err := mr.Set(serviceName, `{"port": 8080}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		manager func() cm.ConfigManager
+	}{
+		{
+			name: "universal options",
+			manager: func() cm.ConfigManager {
+				return NewRedisUniversalConfigManager(serviceName, &redis.UniversalOptions{Addrs: []string{mr.Addr()}})
+			},
+		},
+		{
+			name: "cluster client",
+			manager: func() cm.ConfigManager {
+				cluster := redis.NewClusterClient(&redis.ClusterOptions{Addrs: []string{mr.Addr()}})
+				t.Cleanup(func() { cluster.Close() })
+				return NewRedisConfigManagerWithClient(serviceName, cluster)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manager := tt.manager()
+			defer manager.StopLoading()
+
+			if err := manager.LoadConfig(context.Background()); err != nil {
+				t.Fatalf("LoadConfig failed: %v", err)
+			}
+			if port, err := manager.GetInt("port"); err != nil || port != 8080 {
+				t.Errorf("expected 8080, got %d (err: %v)", port, err)
+			}
+		})
+	}
+}
+
+func TestIsLoaded(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	serviceName := "test_service"
+	rcm := NewRedisConfigManagerWithClient(serviceName, client)
+
+	if rcm.IsLoaded() {
+		t.Error("expected IsLoaded to be false before the first load")
+	}
+
+	if err := rcm.LoadConfig(context.Background()); err == nil {
+		t.Fatal("expected LoadConfig to fail for a missing key")
+	}
+	if rcm.IsLoaded() {
+		t.Error("expected IsLoaded to stay false after a failed load")
+	}
+
+	if err := mr.Set(serviceName, `{}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+	if err := rcm.LoadConfig(context.Background()); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if !rcm.IsLoaded() {
+		t.Error("expected IsLoaded to be true after loading an empty config")
+	}
+}
+
+func TestNewRedisConfigManagerWithContext(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	serviceName := "test_service"
+	if err := mr.Set(serviceName, `{"string_key": "first"}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	rcm := NewRedisConfigManagerWithContext(ctx, serviceName, &redis.Options{Addr: mr.Addr()}).(*RedisConfigManager)
+	defer rcm.StopLoading()
+
+	rcm.StartLoading(10 * time.Millisecond)
+	cancel()
+
+	done := make(chan struct{})
+	go func() {
+		rcm.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("expected cancelling the parent context to stop polling")
+	}
+}
+
+func TestGetIPAndIPNet(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	serviceName := "test_service"
+	document := `{"ip": "10.1.2.3", "ipv6": "::1", "cidr": "10.0.0.0/8", "bad_ip": "10.1.2", "bad_cidr": "10.0.0.0/33"}`
+	if err := mr.Set(serviceName, document); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+
+	rcm := NewRedisConfigManagerWithClient(serviceName, client)
+	if err := rcm.LoadConfig(context.Background()); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	if ip, err := rcm.GetIP("ip"); err != nil || !ip.Equal(net.ParseIP("10.1.2.3")) {
+		t.Errorf("expected 10.1.2.3, got %v (err: %v)", ip, err)
+	}
+	if ip, err := rcm.GetIP("ipv6"); err != nil || !ip.Equal(net.IPv6loopback) {
+		t.Errorf("expected ::1, got %v (err: %v)", ip, err)
+	}
+
+	ipNet, err := rcm.GetIPNet("cidr")
+	if err != nil {
+		t.Fatalf("GetIPNet failed: %v", err)
+	}
+	if !ipNet.Contains(net.ParseIP("10.200.0.1")) || ipNet.Contains(net.ParseIP("11.0.0.1")) {
+		t.Errorf("unexpected range %v", ipNet)
+	}
+
+	if _, err := rcm.GetIP("bad_ip"); err == nil || !strings.Contains(err.Error(), "bad_ip") {
+		t.Errorf("expected an error naming the key, got %v", err)
+	}
+	if _, err := rcm.GetIPNet("bad_cidr"); err == nil || !strings.Contains(err.Error(), "bad_cidr") {
+		t.Errorf("expected an error naming the key, got %v", err)
+	}
+
+	fallback := net.ParseIP("127.0.0.1")
+	if ip := rcm.GetIPWithDefault("bad_ip", fallback); !ip.Equal(fallback) {
+		t.Errorf("expected the default for a malformed value, got %v", ip)
+	}
+}
+
+func TestGetByPrefix(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	serviceName := "test_service"
+	document := `{"feature": {"checkout": {"new_flow": true, "one_click": false}, "search": {"fuzzy": true}}, "port": 8080}`
+	if err := mr.Set(serviceName, document); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+
+	rcm := NewRedisConfigManagerWithClient(serviceName, client).(*RedisConfigManager)
+	if err := rcm.LoadConfig(context.Background()); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	expected := map[string]string{
+		"feature.checkout.new_flow":  "true",
+		"feature.checkout.one_click": "false",
+	}
+	if got := rcm.GetByPrefix("feature.checkout."); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+
+	if got := rcm.GetByPrefix("missing."); len(got) != 0 {
+		t.Errorf("expected no matches, got %v", got)
+	}
+}
+
+func TestGetInt64AndUint64(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	serviceName := "test_service"
+	document := `{"snowflake": 1541815603606036480, "max_bytes": 18446744073709551615, "negative": -5, "float": 1.5}`
+	if err := mr.Set(serviceName, document); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+
+	rcm := NewRedisConfigManagerWithClient(serviceName, client)
+	if err := rcm.LoadConfig(context.Background()); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	if id, err := rcm.GetInt64("snowflake"); err != nil || id != 1541815603606036480 {
+		t.Errorf("expected 1541815603606036480, got %d (err: %v)", id, err)
+	}
+	if size, err := rcm.GetUint64("max_bytes"); err != nil || size != math.MaxUint64 {
+		t.Errorf("expected MaxUint64, got %d (err: %v)", size, err)
+	}
+	if value, err := rcm.GetInt64("negative"); err != nil || value != -5 {
+		t.Errorf("expected -5, got %d (err: %v)", value, err)
+	}
+
+	if _, err := rcm.GetUint64("negative"); err == nil {
+		t.Error("expected an error for a negative uint64")
+	}
+	if _, err := rcm.GetInt64("max_bytes"); err == nil {
+		t.Error("expected an error for an int64 overflow")
+	}
+	if value := rcm.GetInt64WithDefault("float", 7); value != 7 {
+		t.Errorf("expected the default for a fractional value, got %d", value)
+	}
+}
+
+func TestGetStringRejectsObjectsAndArrays(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	serviceName := "test_service"
+	document := `{"db": {"host": "x", "port": 5432}, "hosts": ["a", "b"], "name": "svc"}`
+	if err := mr.Set(serviceName, document); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+
+	rcm := NewRedisConfigManagerWithClient(serviceName, client).(*RedisConfigManager)
+	if err := rcm.LoadConfig(context.Background()); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	for _, key := range []string{"db", "hosts"} {
+		if _, err := rcm.GetString(key); !errors.Is(err, cm.ErrTypeMismatch) {
+			t.Errorf("expected ErrTypeMismatch for %s, got %v", key, err)
+		}
+	}
+	if host, err := rcm.GetString("db.host"); err != nil || host != "x" {
+		t.Errorf("expected x, got %q (err: %v)", host, err)
+	}
+	if hosts, err := rcm.GetStringSlice("hosts"); err != nil || len(hosts) != 2 {
+		t.Errorf("expected two hosts, got %v (err: %v)", hosts, err)
+	}
+
+	db, err := cm.GetJSON[struct {
+		Host string `json:"host"`
+		Port int    `json:"port"`
+	}](rcm, "db")
+	if err != nil || db.Host != "x" || db.Port != 5432 {
+		t.Errorf("expected GetJSON to decode the object, got %+v (err: %v)", db, err)
+	}
+
+	rcm.Override("name", map[string]any{"first": "a"})
+	if _, err := rcm.GetString("name"); !errors.Is(err, cm.ErrTypeMismatch) {
+		t.Errorf("expected ErrTypeMismatch for an object override, got %v", err)
+	}
+	rcm.Override("db", "plain")
+	if value, err := rcm.GetString("db"); err != nil || value != "plain" {
+		t.Errorf("expected the string override, got %q (err: %v)", value, err)
+	}
+}
+
+func TestGetStringMatching(t *testing.T) {
+	rcm := &RedisConfigManager{
+		serviceName: "test_service",
+		config:      map[string]string{"version": "1.4.2", "bad_version": "latest"},
+	}
+	semver := regexp.MustCompile(`^\d+\.\d+\.\d+$`)
+
+	value, err := rcm.GetStringMatching("version", semver)
+	if err != nil || value != "1.4.2" {
+		t.Errorf("expected '1.4.2', got '%s' (%v)", value, err)
+	}
+
+	_, err = rcm.GetStringMatching("bad_version", semver)
+	if err == nil {
+		t.Fatal("expected error for a value that does not match")
+	}
+	for _, part := range []string{"bad_version", "latest", semver.String()} {
+		if !strings.Contains(err.Error(), part) {
+			t.Errorf("expected error to contain %q, got %v", part, err)
+		}
+	}
+
+	if value := rcm.GetStringMatchingWithDefault("bad_version", semver, "0.0.0"); value != "0.0.0" {
+		t.Errorf("expected default '0.0.0' for a mismatch, got '%s'", value)
+	}
+	if value := rcm.GetStringMatchingWithDefault("nonexistent_key", semver, "0.0.0"); value != "0.0.0" {
+		t.Errorf("expected default '0.0.0' for a missing key, got '%s'", value)
+	}
+}
+
+func TestClose(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("failed to start miniredis: %v", err)
+	}
+	defer mr.Close()
+
+	if err := mr.Set("test_service", `{"string_key": "value"}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+
+	var closer io.Closer = NewRedisConfigManager("test_service", &redis.Options{Addr: mr.Addr()}).(*RedisConfigManager)
+	rcm := closer.(*RedisConfigManager)
+	rcm.StartLoading(20 * time.Millisecond)
+
+	if err := closer.Close(); err != nil {
+		t.Errorf("expected Close to succeed, got %v", err)
+	}
+	if err := rcm.r.Ping(context.Background()).Err(); err == nil {
+		t.Error("expected Close to close the owned client")
+	}
+	if err := closer.Close(); err != nil {
+		t.Errorf("expected a second Close to succeed, got %v", err)
+	}
+}
+
+func TestGetDurationMillis(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	serviceName := "test_service"
+	if err := mr.Set(serviceName, `{"legacy_timeout": 5000, "quoted_timeout": "5000", "timeout": "5s", "bad": "soon"}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+
+	rcm := NewRedisConfigManagerWithClient(serviceName, client)
+	if err := rcm.LoadConfig(context.Background()); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	for _, key := range []string{"legacy_timeout", "quoted_timeout", "timeout"} {
+		if value, err := rcm.GetDurationMillis(key); err != nil || value != 5*time.Second {
+			t.Errorf("%s: expected 5s, got %v (err: %v)", key, value, err)
+		}
+	}
+	if _, err := rcm.GetDuration("quoted_timeout"); err == nil {
+		t.Error("expected GetDuration to keep rejecting bare numbers")
+	}
+	if value := rcm.GetDurationMillisWithDefault("bad", time.Second); value != time.Second {
+		t.Errorf("expected the default for an invalid value, got %v", value)
+	}
+}
+
+func TestLoadConfig_NullValues(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	serviceName := "test_service"
+	if err := mr.Set(serviceName, `{"timeout": null, "name": "svc", "db": {"host": null}}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+
+	rcm := NewRedisConfigManagerWithClient(serviceName, client)
+	if err := rcm.LoadConfig(context.Background()); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	for _, key := range []string{"timeout", "db.host"} {
+		if value, err := rcm.GetString(key); !errors.Is(err, cm.ErrKeyNotFound) {
+			t.Errorf("expected a null %s to read as missing, got %q (err: %v)", key, value, err)
+		}
+	}
+	if value := rcm.GetDurationWithDefault("timeout", time.Second); value != time.Second {
+		t.Errorf("expected the default for a null value, got %v", value)
+	}
+	if name, _ := rcm.GetString("name"); name != "svc" {
+		t.Errorf("expected svc, got %q", name)
+	}
+}
+
+func TestGetColorRGBA(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	serviceName := "test_service"
+	if err := mr.Set(serviceName, `{"theme": {"primary": "#1e90ff", "overlay": "00000080", "tint": "#ff000080", "text": "#fff", "broken": "blue"}}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+
+	rcm := NewRedisConfigManagerWithClient(serviceName, client)
+	if err := rcm.LoadConfig(context.Background()); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	expected := map[string]color.RGBA{
+		"theme.primary": {R: 0x1e, G: 0x90, B: 0xff, A: 0xff},
+		"theme.overlay": {A: 0x80},
+		"theme.tint":    {R: 0x80, A: 0x80},
+		"theme.text":    {R: 0xff, G: 0xff, B: 0xff, A: 0xff},
+	}
+	for key, want := range expected {
+		if value, err := rcm.GetColorRGBA(key); err != nil || value != want {
+			t.Errorf("%s: expected %v, got %v (err: %v)", key, want, value, err)
+		}
+	}
+
+	if _, err := rcm.GetColorRGBA("theme.broken"); err == nil || !strings.Contains(err.Error(), "theme.broken") {
+		t.Errorf("expected an error naming the key, got %v", err)
+	}
+	if _, err := rcm.GetColorRGBA("missing"); !errors.Is(err, cm.ErrKeyNotFound) {
+		t.Errorf("expected ErrKeyNotFound, got %v", err)
 	}
 
-	for i := 0; i < concurrency; i++ {
-		<-done
+	fallback := color.RGBA{A: 0xff}
+	if value := rcm.GetColorRGBAWithDefault("theme.broken", fallback); value != fallback {
+		t.Errorf("expected the default for an invalid color, got %v", value)
 	}
 }
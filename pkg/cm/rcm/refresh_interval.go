@@ -0,0 +1,44 @@
+package rcm
+
+import (
+	"errors"
+	"math/rand/v2"
+	"time"
+
+	"github.com/zemld/config-manager/pkg/cm"
+)
+
+const (
+	// RefreshIntervalKey is the config key that, when present, overrides
+	// the polling interval of StartLoading and its variants.
+	RefreshIntervalKey = "_refresh_interval"
+
+	minRefreshInterval = time.Second
+)
+
+// refreshInterval returns the interval set under RefreshIntervalKey, or
+// fallback if the key is absent or cannot be parsed. Values below one
+// second are raised to one second so a typo cannot turn polling into a
+// busy loop. A fallback that is zero or negative is raised likewise.
+func (rcm *RedisConfigManager) refreshInterval(fallback time.Duration) time.Duration {
+	if fallback <= 0 {
+		fallback = minRefreshInterval
+	}
+
+	interval, err := rcm.GetDuration(RefreshIntervalKey)
+	if errors.Is(err, cm.ErrKeyNotFound) {
+		return fallback
+	}
+	if err != nil && !errors.Is(err, cm.ErrStale) {
+		rcm.log().Warnf("ignoring %s: %v", RefreshIntervalKey, err)
+		return fallback
+	}
+
+	return max(interval, minRefreshInterval)
+}
+
+// nextInterval returns the wait before the next reload: the interval set
+// under RefreshIntervalKey or interval, spread by jitter.
+func (rcm *RedisConfigManager) nextInterval(random *rand.Rand, interval, jitter time.Duration) time.Duration {
+	return jitteredInterval(random, rcm.refreshInterval(interval), jitter)
+}
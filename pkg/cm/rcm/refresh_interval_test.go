@@ -0,0 +1,88 @@
+package rcm
+
+import (
+	"math/rand/v2"
+	"testing"
+	"time"
+)
+
+func TestRefreshInterval(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   map[string]string
+		expected time.Duration
+	}{
+		{"missing key", map[string]string{}, time.Minute},
+		{"configured", map[string]string{RefreshIntervalKey: "30s"}, 30 * time.Second},
+		{"below minimum", map[string]string{RefreshIntervalKey: "10ms"}, time.Second},
+		{"negative", map[string]string{RefreshIntervalKey: "-5s"}, time.Second},
+		{"invalid", map[string]string{RefreshIntervalKey: "soon"}, time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rcm := &RedisConfigManager{config: tt.config}
+			if got := rcm.refreshInterval(time.Minute); got != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestStartLoading_RefreshIntervalFromConfig(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	if err := mr.Set("myservice", `{"_refresh_interval": "1s", "version": "v1"}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+
+	rcm := NewRedisConfigManagerWithClient("myservice", client).(*RedisConfigManager)
+	rcm.StartLoading(time.Hour)
+	defer rcm.StopLoading()
+
+	if err := mr.Set("myservice", `{"_refresh_interval": "1s", "version": "v2"}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+
+	deadline := time.Now().Add(3 * time.Second)
+	for time.Now().Before(deadline) {
+		if version, _ := rcm.GetString("version"); version == "v2" {
+			return
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	t.Error("expected the interval from the config to replace the hourly one")
+}
+
+func TestNextInterval_JitterOnRefreshInterval(t *testing.T) {
+	rcm := &RedisConfigManager{config: map[string]string{RefreshIntervalKey: "10s"}}
+	random := rand.New(rand.NewPCG(1, 2))
+
+	seen := make(map[time.Duration]bool)
+	for i := 0; i < 100; i++ {
+		value := rcm.nextInterval(random, time.Hour, 2*time.Second)
+		if value < 8*time.Second || value > 12*time.Second {
+			t.Fatalf("expected a wait within 2s of the configured 10s, got %v", value)
+		}
+		seen[value] = true
+	}
+	if len(seen) < 2 {
+		t.Error("expected the jitter to spread the configured interval")
+	}
+}
+
+func TestNextInterval_NonPositiveInterval(t *testing.T) {
+	rcm := &RedisConfigManager{config: map[string]string{}}
+	random := rand.New(rand.NewPCG(1, 2))
+
+	for _, interval := range []time.Duration{0, -time.Second} {
+		if got := rcm.nextInterval(random, interval, 0); got != minRefreshInterval {
+			t.Errorf("interval %v: expected %v, got %v", interval, minRefreshInterval, got)
+		}
+		if got := rcm.nextInterval(random, interval, time.Hour); got < minRefreshInterval/2 {
+			t.Errorf("interval %v with jitter: expected a positive wait, got %v", interval, got)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package rcm
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// WithRetry makes LoadConfig retry transient Redis failures, such as
+// dropped connections or a server that is still loading its dataset, up to
+// maxAttempts times in total. The delay starts at baseDelay and doubles after
+// every attempt. Retries stop early when the context is done.
+func WithRetry(maxAttempts int, baseDelay time.Duration) Option {
+	return func(rcm *RedisConfigManager) {
+		rcm.retryAttempts = maxAttempts
+		rcm.retryDelay = baseDelay
+	}
+}
+
+func (rcm *RedisConfigManager) loadWithRetry(ctx context.Context, client redis.UniversalClient) error {
+	delay := rcm.retryDelay
+
+	for attempt := 1; ; attempt++ {
+		err := rcm.load(ctx, client)
+		if err == nil || attempt >= rcm.retryAttempts || !isTransient(err) {
+			return err
+		}
+
+		rcm.log().Warnf("loading config %s failed (attempt %d of %d), retrying in %s: %v",
+			rcm.configKey(), attempt, rcm.retryAttempts, delay, err)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+
+		delay *= 2
+	}
+}
+
+// transientServerErrors are the prefixes of Redis errors that go away on
+// their own, typically during a restart or failover.
+var transientServerErrors = []string{"LOADING", "BUSY", "TRYAGAIN", "CLUSTERDOWN", "MASTERDOWN", "READONLY"}
+
+// isTransient reports whether err is worth retrying. A missing key, a
+// malformed document or a rejected config will fail the same way again.
+func isTransient(err error) bool {
+	if errors.Is(err, redis.Nil) || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) || errors.Is(err, io.EOF) {
+		return true
+	}
+
+	var redisErr redis.Error
+	if errors.As(err, &redisErr) {
+		for _, prefix := range transientServerErrors {
+			if strings.HasPrefix(redisErr.Error(), prefix) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
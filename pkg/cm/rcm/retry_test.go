@@ -0,0 +1,79 @@
+package rcm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+func TestWithRetry(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	serviceName := "test_service"
+	if err := mr.Set(serviceName, `{"string_key": "value"}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+
+	mr.SetError("LOADING Redis is loading the dataset in memory")
+	timer := time.AfterFunc(30*time.Millisecond, func() { mr.SetError("") })
+	defer timer.Stop()
+
+	rcm := NewRedisConfigManagerWithClient(serviceName, client, WithRetry(6, 10*time.Millisecond))
+	if err := rcm.LoadConfig(context.Background()); err != nil {
+		t.Fatalf("expected the transient error to be retried, got %v", err)
+	}
+	if value, _ := rcm.GetString("string_key"); value != "value" {
+		t.Errorf("expected value, got %q", value)
+	}
+}
+
+func TestWithRetry_HonorsContext(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	mr.SetError("LOADING Redis is loading the dataset in memory")
+
+	rcm := NewRedisConfigManagerWithClient("test_service", client, WithRetry(10, time.Second))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	if err := rcm.LoadConfig(ctx); err == nil {
+		t.Fatal("expected LoadConfig to fail")
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("expected retries to stop when the context is done, took %v", elapsed)
+	}
+}
+
+func TestIsTransient(t *testing.T) {
+	tests := []struct {
+		err       error
+		transient bool
+	}{
+		{fmt.Errorf("failed to get config: %w", redis.Nil), false},
+		{errors.New("failed to unmarshal config"), false},
+		{context.DeadlineExceeded, false},
+		{fmt.Errorf("failed to get config: %w", &timeoutError{}), true},
+	}
+
+	for _, tt := range tests {
+		if got := isTransient(tt.err); got != tt.transient {
+			t.Errorf("isTransient(%v): expected %v, got %v", tt.err, tt.transient, got)
+		}
+	}
+}
+
+type timeoutError struct{}
+
+func (*timeoutError) Error() string   { return "i/o timeout" }
+func (*timeoutError) Timeout() bool   { return true }
+func (*timeoutError) Temporary() bool { return true }
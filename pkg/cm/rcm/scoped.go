@@ -0,0 +1,222 @@
+package rcm
+
+import (
+	"context"
+	"image/color"
+	"net"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/zemld/config-manager/pkg/cm"
+)
+
+// Scoped returns a view of the config under prefix, so that GetString("host")
+// on the view reads "prefix.host". A prefix that already ends in a dot is used
+// as is. The view reads the manager's live config, so reloads are visible
+// through it. Loading stays with the parent: StartLoading and StopLoading on
+// the view do nothing, while LoadConfig reloads the parent.
+func (rcm *RedisConfigManager) Scoped(prefix string) cm.ConfigManager {
+	if prefix != "" && !strings.HasSuffix(prefix, ".") {
+		prefix += "."
+	}
+
+	return &scopedManager{
+		parent: rcm,
+		prefix: prefix,
+	}
+}
+
+type scopedManager struct {
+	parent *RedisConfigManager
+	prefix string
+}
+
+func (s *scopedManager) StartLoading(interval time.Duration) {}
+
+func (s *scopedManager) StopLoading() {}
+
+func (s *scopedManager) LoadConfig(ctx context.Context) error {
+	return s.parent.LoadConfig(ctx)
+}
+
+func (s *scopedManager) LastUpdated() time.Time {
+	return s.parent.LastUpdated()
+}
+
+func (s *scopedManager) IsLoaded() bool {
+	return s.parent.IsLoaded()
+}
+
+func (s *scopedManager) GetInt(key string) (int, error) {
+	return s.parent.GetInt(s.prefix + key)
+}
+
+func (s *scopedManager) GetFloat(key string) (float64, error) {
+	return s.parent.GetFloat(s.prefix + key)
+}
+
+func (s *scopedManager) GetString(key string) (string, error) {
+	return s.parent.GetString(s.prefix + key)
+}
+
+func (s *scopedManager) GetRaw(key string) (string, error) {
+	return s.parent.GetRaw(s.prefix + key)
+}
+
+func (s *scopedManager) GetBool(key string) (bool, error) {
+	return s.parent.GetBool(s.prefix + key)
+}
+
+func (s *scopedManager) GetDuration(key string) (time.Duration, error) {
+	return s.parent.GetDuration(s.prefix + key)
+}
+
+func (s *scopedManager) GetStringSlice(key string) ([]string, error) {
+	return s.parent.GetStringSlice(s.prefix + key)
+}
+
+func (s *scopedManager) GetBytes(key string) ([]byte, error) {
+	return s.parent.GetBytes(s.prefix + key)
+}
+
+func (s *scopedManager) GetIntSlice(key string) ([]int, error) {
+	return s.parent.GetIntSlice(s.prefix + key)
+}
+
+func (s *scopedManager) GetFloatSlice(key string) ([]float64, error) {
+	return s.parent.GetFloatSlice(s.prefix + key)
+}
+
+func (s *scopedManager) GetStringMap(key string) (map[string]string, error) {
+	return s.parent.GetStringMap(s.prefix + key)
+}
+
+func (s *scopedManager) GetURL(key string) (*url.URL, error) {
+	return s.parent.GetURL(s.prefix + key)
+}
+
+func (s *scopedManager) GetEnum(key string, allowed []string) (string, error) {
+	return s.parent.GetEnum(s.prefix+key, allowed)
+}
+
+func (s *scopedManager) GetIP(key string) (net.IP, error) {
+	return s.parent.GetIP(s.prefix + key)
+}
+
+func (s *scopedManager) GetIPNet(key string) (*net.IPNet, error) {
+	return s.parent.GetIPNet(s.prefix + key)
+}
+
+func (s *scopedManager) GetInt64(key string) (int64, error) {
+	return s.parent.GetInt64(s.prefix + key)
+}
+
+func (s *scopedManager) GetUint64(key string) (uint64, error) {
+	return s.parent.GetUint64(s.prefix + key)
+}
+
+func (s *scopedManager) GetBytesSize(key string) (int64, error) {
+	return s.parent.GetBytesSize(s.prefix + key)
+}
+
+func (s *scopedManager) GetPercent(key string) (float64, error) {
+	return s.parent.GetPercent(s.prefix + key)
+}
+
+func (s *scopedManager) GetStringMatching(key string, re *regexp.Regexp) (string, error) {
+	return s.parent.GetStringMatching(s.prefix+key, re)
+}
+
+func (s *scopedManager) GetDurationMillis(key string) (time.Duration, error) {
+	return s.parent.GetDurationMillis(s.prefix + key)
+}
+
+func (s *scopedManager) GetColorRGBA(key string) (color.RGBA, error) {
+	return s.parent.GetColorRGBA(s.prefix + key)
+}
+
+func (s *scopedManager) GetIntWithDefault(key string, defaultValue int) int {
+	return s.parent.GetIntWithDefault(s.prefix+key, defaultValue)
+}
+
+func (s *scopedManager) GetFloatWithDefault(key string, defaultValue float64) float64 {
+	return s.parent.GetFloatWithDefault(s.prefix+key, defaultValue)
+}
+
+func (s *scopedManager) GetStringWithDefault(key string, defaultValue string) string {
+	return s.parent.GetStringWithDefault(s.prefix+key, defaultValue)
+}
+
+func (s *scopedManager) GetBoolWithDefault(key string, defaultValue bool) bool {
+	return s.parent.GetBoolWithDefault(s.prefix+key, defaultValue)
+}
+
+func (s *scopedManager) GetDurationWithDefault(key string, defaultValue time.Duration) time.Duration {
+	return s.parent.GetDurationWithDefault(s.prefix+key, defaultValue)
+}
+
+func (s *scopedManager) GetStringSliceWithDefault(key string, defaultValue []string) []string {
+	return s.parent.GetStringSliceWithDefault(s.prefix+key, defaultValue)
+}
+
+func (s *scopedManager) GetBytesWithDefault(key string, defaultValue []byte) []byte {
+	return s.parent.GetBytesWithDefault(s.prefix+key, defaultValue)
+}
+
+func (s *scopedManager) GetIntSliceWithDefault(key string, defaultValue []int) []int {
+	return s.parent.GetIntSliceWithDefault(s.prefix+key, defaultValue)
+}
+
+func (s *scopedManager) GetFloatSliceWithDefault(key string, defaultValue []float64) []float64 {
+	return s.parent.GetFloatSliceWithDefault(s.prefix+key, defaultValue)
+}
+
+func (s *scopedManager) GetStringMapWithDefault(key string, defaultValue map[string]string) map[string]string {
+	return s.parent.GetStringMapWithDefault(s.prefix+key, defaultValue)
+}
+
+func (s *scopedManager) GetURLWithDefault(key string, defaultValue *url.URL) *url.URL {
+	return s.parent.GetURLWithDefault(s.prefix+key, defaultValue)
+}
+
+func (s *scopedManager) GetEnumWithDefault(key string, allowed []string, defaultValue string) string {
+	return s.parent.GetEnumWithDefault(s.prefix+key, allowed, defaultValue)
+}
+
+func (s *scopedManager) GetIPWithDefault(key string, defaultValue net.IP) net.IP {
+	return s.parent.GetIPWithDefault(s.prefix+key, defaultValue)
+}
+
+func (s *scopedManager) GetIPNetWithDefault(key string, defaultValue *net.IPNet) *net.IPNet {
+	return s.parent.GetIPNetWithDefault(s.prefix+key, defaultValue)
+}
+
+func (s *scopedManager) GetInt64WithDefault(key string, defaultValue int64) int64 {
+	return s.parent.GetInt64WithDefault(s.prefix+key, defaultValue)
+}
+
+func (s *scopedManager) GetUint64WithDefault(key string, defaultValue uint64) uint64 {
+	return s.parent.GetUint64WithDefault(s.prefix+key, defaultValue)
+}
+
+func (s *scopedManager) GetBytesSizeWithDefault(key string, defaultValue int64) int64 {
+	return s.parent.GetBytesSizeWithDefault(s.prefix+key, defaultValue)
+}
+
+func (s *scopedManager) GetPercentWithDefault(key string, defaultValue float64) float64 {
+	return s.parent.GetPercentWithDefault(s.prefix+key, defaultValue)
+}
+
+func (s *scopedManager) GetStringMatchingWithDefault(key string, re *regexp.Regexp, defaultValue string) string {
+	return s.parent.GetStringMatchingWithDefault(s.prefix+key, re, defaultValue)
+}
+
+func (s *scopedManager) GetDurationMillisWithDefault(key string, defaultValue time.Duration) time.Duration {
+	return s.parent.GetDurationMillisWithDefault(s.prefix+key, defaultValue)
+}
+
+func (s *scopedManager) GetColorRGBAWithDefault(key string, defaultValue color.RGBA) color.RGBA {
+	return s.parent.GetColorRGBAWithDefault(s.prefix+key, defaultValue)
+}
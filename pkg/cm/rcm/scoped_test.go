@@ -0,0 +1,48 @@
+package rcm
+
+import (
+	"context"
+	"testing"
+)
+
+func TestScoped(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	serviceName := "test_service"
+	if err := mr.Set(serviceName, `{"db": {"host": "localhost", "port": 5432}, "host": "top"}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+
+	rcm := NewRedisConfigManagerWithClient(serviceName, client).(*RedisConfigManager)
+	if err := rcm.LoadConfig(context.Background()); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	for _, prefix := range []string{"db", "db."} {
+		db := rcm.Scoped(prefix)
+
+		if host, err := db.GetString("host"); err != nil || host != "localhost" {
+			t.Errorf("prefix %q: expected localhost, got %q (err: %v)", prefix, host, err)
+		}
+		if port, err := db.GetInt("port"); err != nil || port != 5432 {
+			t.Errorf("prefix %q: expected 5432, got %d (err: %v)", prefix, port, err)
+		}
+	}
+
+	db := rcm.Scoped("db")
+	if err := mr.Set(serviceName, `{"db": {"host": "replica"}}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+	if err := rcm.LoadConfig(context.Background()); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	if host, _ := db.GetString("host"); host != "replica" {
+		t.Errorf("expected the view to see the reloaded value, got %q", host)
+	}
+	if port := db.GetIntWithDefault("port", 1); port != 1 {
+		t.Errorf("expected the default for a removed key, got %d", port)
+	}
+}
@@ -0,0 +1,153 @@
+package rcm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/zemld/config-manager/pkg/cm/internal/conv"
+)
+
+const maxSetAttempts = 10
+
+// Set stores value under the top-level key of the config document in Redis
+// and updates the local cache. The fallback, defaults and source documents
+// are merged in as on LoadConfig, and the validator sees the merged config.
+//
+// This is a convenience helper for admin tooling. The read-modify-write runs
+// under WATCH/MULTI/EXEC, and when another process modifies the document in
+// between, the transaction is retried on the fresh document. Managers backed
+// by a hash write the single field instead. A document rejected by the
+// validator is not written. With WithVersionKey, the version is bumped in the
+// same transaction as the write. A cluster client cannot run both in one
+// transaction, since the two keys usually hash to different slots, so there
+// the version is bumped right after the write instead.
+func (rcm *RedisConfigManager) Set(ctx context.Context, key string, value any) error {
+	var layered map[string]any
+	var version string
+	configKey := rcm.configKey()
+	client := rcm.client()
+	_, cluster := client.(*redis.ClusterClient)
+
+	update := func(tx *redis.Tx) error {
+		document, write, err := rcm.stageSet(ctx, tx, configKey, key, value)
+		if err != nil {
+			return err
+		}
+
+		layered, err = rcm.layerDocument(ctx, client, document)
+		if err != nil {
+			return err
+		}
+		if _, err := rcm.buildConfig(layered); err != nil {
+			return err
+		}
+
+		var bump *redis.IntCmd
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			write(pipe)
+			if !cluster {
+				bump = rcm.bumpVersion(ctx, pipe)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		if bump != nil {
+			version = strconv.FormatInt(bump.Val(), 10)
+		}
+		return nil
+	}
+
+	for attempt := 0; attempt < maxSetAttempts; attempt++ {
+		err := client.Watch(ctx, update, configKey)
+		if errors.Is(err, redis.TxFailedErr) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to set %s: %w", key, err)
+		}
+
+		if !cluster {
+			return rcm.applyConfig(configKey, version, layered)
+		}
+
+		// Without a version recorded, the next load fetches the document
+		// again rather than trusting a bump that is not atomic with it.
+		if err := rcm.applyConfig(configKey, "", layered); err != nil {
+			return err
+		}
+		if bump := rcm.bumpVersion(ctx, client); bump != nil && bump.Err() != nil {
+			return fmt.Errorf("failed to bump config version: %w", bump.Err())
+		}
+		return nil
+	}
+
+	return fmt.Errorf("failed to set %s: document kept changing after %d attempts", key, maxSetAttempts)
+}
+
+// stageSet reads the stored config with key set to value and returns it with
+// the write that stores the change. The write is prepared before the document
+// is layered, since layering may modify its nested maps.
+func (rcm *RedisConfigManager) stageSet(ctx context.Context, tx *redis.Tx, configKey, key string, value any) (map[string]any, func(redis.Pipeliner), error) {
+	if rcm.hash {
+		document, err := rcm.readHash(ctx, tx, configKey)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		field := conv.Stringify(value)
+		document[key] = field
+		return document, func(pipe redis.Pipeliner) {
+			pipe.HSet(ctx, configKey, key, field)
+		}, nil
+	}
+
+	document, err := rcm.readDocument(ctx, tx, configKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	document[key] = value
+	encoded, err := rcm.encode(document)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal config: %w", err)
+	}
+
+	return document, func(pipe redis.Pipeliner) {
+		pipe.SetArgs(ctx, configKey, encoded, redis.SetArgs{KeepTTL: true})
+	}, nil
+}
+
+// readDocument returns the config document stored under key, or an empty one
+// if the key does not exist yet.
+func (rcm *RedisConfigManager) readDocument(ctx context.Context, tx *redis.Tx, key string) (map[string]any, error) {
+	rawConfig, err := tx.Get(ctx, key).Result()
+	if errors.Is(err, redis.Nil) {
+		return make(map[string]any), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get config: %w", err)
+	}
+
+	document, err := rcm.decode([]byte(rawConfig))
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
+	}
+
+	return document, nil
+}
+
+// bumpVersion runs or, on a pipeline, queues an INCR of the version key, so
+// that other managers reload after a Set. It returns nil when versioning is
+// off.
+func (rcm *RedisConfigManager) bumpVersion(ctx context.Context, c redis.Cmdable) *redis.IntCmd {
+	if rcm.versionKey == "" {
+		return nil
+	}
+
+	return c.Incr(ctx, rcm.versionKey)
+}
@@ -0,0 +1,121 @@
+package rcm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+)
+
+func TestSet(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	serviceName := "test_service"
+	if err := mr.Set(serviceName, `{"int_key": 42, "string_key": "old"}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+
+	rcm := &RedisConfigManager{
+		serviceName: serviceName,
+		config:      make(map[string]string),
+		r:           client,
+		ctx:         context.Background(),
+	}
+
+	if err := rcm.Set(context.Background(), "string_key", "new"); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+
+	value, err := rcm.GetString("string_key")
+	if err != nil || value != "new" {
+		t.Errorf("expected local cache to hold 'new', got '%s' (%v)", value, err)
+	}
+
+	if err := rcm.LoadConfig(context.Background()); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	value, err = rcm.GetString("string_key")
+	if err != nil || value != "new" {
+		t.Errorf("expected Redis to hold 'new', got '%s' (%v)", value, err)
+	}
+
+	intValue, err := rcm.GetInt("int_key")
+	if err != nil || intValue != 42 {
+		t.Errorf("expected other keys to be preserved, got %d (%v)", intValue, err)
+	}
+}
+
+func TestSet_MissingDocument(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	rcm := &RedisConfigManager{
+		serviceName: "test_service",
+		config:      make(map[string]string),
+		r:           client,
+		ctx:         context.Background(),
+	}
+
+	if err := rcm.Set(context.Background(), "bool_key", true); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+
+	stored, err := mr.Get("test_service")
+	if err != nil {
+		t.Fatalf("failed to read config from miniredis: %v", err)
+	}
+	if stored != `{"bool_key":true}` {
+		t.Errorf("unexpected stored document: %s", stored)
+	}
+}
+
+func TestSet_KeepsFallbackKeys(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	if err := mr.Set("service", `{"timeout": "1s"}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+	if err := mr.Set("shared", `{"timeout": "3s", "region": "eu"}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+
+	rcm := NewRedisConfigManager("service", &redis.Options{Addr: mr.Addr()}, WithFallbacks("shared"))
+	defer rcm.StopLoading()
+
+	var validated map[string]string
+	rcm.(*RedisConfigManager).SetValidator(func(config map[string]string) error {
+		validated = config
+		return nil
+	})
+	if err := rcm.LoadConfig(context.Background()); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	if err := rcm.(*RedisConfigManager).Set(context.Background(), "retries", 3); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+
+	if value, err := rcm.GetString("region"); err != nil || value != "eu" {
+		t.Errorf("expected the fallback key to survive Set, got '%s' (%v)", value, err)
+	}
+	if value, err := rcm.GetString("timeout"); err != nil || value != "1s" {
+		t.Errorf("expected the service key to win, got '%s' (%v)", value, err)
+	}
+	if validated["region"] != "eu" || validated["retries"] != "3" {
+		t.Errorf("expected the validator to see the merged config, got %v", validated)
+	}
+
+	stored, err := mr.Get("service")
+	if err != nil {
+		t.Fatalf("failed to read config from miniredis: %v", err)
+	}
+	if stored != `{"retries":3,"timeout":"1s"}` {
+		t.Errorf("expected only the service document to be written, got %s", stored)
+	}
+}
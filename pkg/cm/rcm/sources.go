@@ -0,0 +1,76 @@
+package rcm
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/redis/go-redis/v9"
+)
+
+type source struct {
+	client   redis.UniversalClient
+	key      string
+	priority int
+}
+
+// AddSource makes LoadConfig also read the document under key through
+// client, which may point at another Redis database or server, and merge it
+// with the manager's own config. Objects are merged recursively and, for
+// each key, the value from the source with the highest priority wins. The
+// manager's own key, together with its fallback keys, has priority 0 and
+// wins ties; sources with equal priority win over those added before them.
+// A source whose key does not exist is skipped. The client is not closed by
+// StopLoading, and StartWatching only watches the manager's own key.
+//
+// For example, shared platform settings can sit below the service config:
+//
+//	rcm.AddSource(platformClient, "platform", -1)
+func (rcm *RedisConfigManager) AddSource(client redis.UniversalClient, key string, priority int) {
+	rcm.mu.Lock()
+	defer rcm.mu.Unlock()
+
+	sources := append(slices.Clone(rcm.sources), source{client: client, key: key, priority: priority})
+	slices.SortStableFunc(sources, func(a, b source) int {
+		return cmp.Compare(a.priority, b.priority)
+	})
+	rcm.sources = sources
+}
+
+// mergeSources merges the documents of the added sources with primary in
+// ascending order of priority and returns the result. Without sources it
+// returns primary unchanged.
+func (rcm *RedisConfigManager) mergeSources(ctx context.Context, primary map[string]any) (map[string]any, error) {
+	rcm.mu.RLock()
+	sources := rcm.sources
+	rcm.mu.RUnlock()
+
+	if len(sources) == 0 {
+		return primary, nil
+	}
+
+	merged := make(map[string]any)
+	primaryMerged := false
+	for _, s := range sources {
+		if !primaryMerged && s.priority > 0 {
+			mergeDocument(merged, primary)
+			primaryMerged = true
+		}
+
+		document, err := rcm.fetchDocument(ctx, s.client, s.key)
+		if errors.Is(err, redis.Nil) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("source %s: %w", s.key, err)
+		}
+		mergeDocument(merged, document)
+	}
+	if !primaryMerged {
+		mergeDocument(merged, primary)
+	}
+
+	return merged, nil
+}
@@ -0,0 +1,118 @@
+package rcm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+)
+
+func TestAddSource(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	platformClient := redis.NewClient(&redis.Options{Addr: mr.Addr(), DB: 3})
+	defer platformClient.Close()
+
+	if err := mr.DB(3).Set("platform", `{"region": "eu", "log": {"level": "info", "format": "json"}, "timeout": "5s"}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+	if err := mr.Set("myservice", `{"log": {"level": "debug"}, "timeout": "1s"}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+	if err := mr.Set("pinned", `{"timeout": "30s"}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+
+	rcm := NewRedisConfigManagerWithClient("myservice", client).(*RedisConfigManager)
+	rcm.AddSource(client, "pinned", 10)
+	rcm.AddSource(platformClient, "platform", -1)
+	rcm.AddSource(client, "missing", 5)
+
+	if err := rcm.LoadConfig(context.Background()); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	expected := map[string]string{
+		"region":     "eu",
+		"log.level":  "debug",
+		"log.format": "json",
+		"timeout":    "30s",
+	}
+	for key, want := range expected {
+		if got, err := rcm.GetString(key); err != nil || got != want {
+			t.Errorf("%s: expected %q, got %q (err: %v)", key, want, got, err)
+		}
+	}
+}
+
+func TestAddSource_PrimaryWinsTies(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	if err := mr.Set("shared", `{"value": "shared"}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+	if err := mr.Set("myservice", `{"value": "own"}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+
+	rcm := NewRedisConfigManagerWithClient("myservice", client).(*RedisConfigManager)
+	rcm.AddSource(client, "shared", 0)
+
+	if err := rcm.LoadConfig(context.Background()); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if value, _ := rcm.GetString("value"); value != "own" {
+		t.Errorf("expected the manager's own key to win a tie, got %q", value)
+	}
+}
+
+func TestAddSource_Set(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	if err := mr.Set("platform", `{"region": "eu"}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+	if err := mr.Set("pinned", `{"log": {"format": "text"}}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+	if err := mr.Set("myservice", `{"log": {"level": "debug"}}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+
+	rcm := NewRedisConfigManagerWithClient("myservice", client).(*RedisConfigManager)
+	rcm.AddSource(client, "platform", -1)
+	rcm.AddSource(client, "pinned", 10)
+	if err := rcm.LoadConfig(context.Background()); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	if err := rcm.Set(context.Background(), "retries", 3); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+
+	expected := map[string]string{
+		"region":     "eu",
+		"log.level":  "debug",
+		"log.format": "text",
+		"retries":    "3",
+	}
+	for key, want := range expected {
+		if got, err := rcm.GetString(key); err != nil || got != want {
+			t.Errorf("%s: expected %q after Set, got %q (err: %v)", key, want, got, err)
+		}
+	}
+
+	stored, err := mr.Get("myservice")
+	if err != nil {
+		t.Fatalf("failed to read config from miniredis: %v", err)
+	}
+	if stored != `{"log":{"level":"debug"},"retries":3}` {
+		t.Errorf("expected source keys not to be written to the service document, got %s", stored)
+	}
+}
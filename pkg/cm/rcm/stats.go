@@ -0,0 +1,37 @@
+package rcm
+
+import "time"
+
+// Stats is a point-in-time copy of the manager's state for diagnostics. It
+// holds no references into the manager, so it is safe to keep and
+// serialize.
+type Stats struct {
+	Loads       int64     `json:"loads"`
+	LoadErrors  int64     `json:"load_errors"`
+	LastError   string    `json:"last_error,omitempty"`
+	LastUpdated time.Time `json:"last_updated"`
+	Keys        int       `json:"keys"`
+	Polling     bool      `json:"polling"`
+}
+
+// Stats returns the load counters, the last error, the time of the last
+// successful load, the number of loaded keys and whether a poll loop started
+// by StartLoading is running. Polling turns false as soon as the loop exits,
+// including when the parent context is cancelled.
+func (rcm *RedisConfigManager) Stats() Stats {
+	rcm.mu.RLock()
+	defer rcm.mu.RUnlock()
+
+	stats := Stats{
+		Loads:       rcm.loads,
+		LoadErrors:  rcm.loadErrors,
+		LastUpdated: rcm.updatedAt,
+		Keys:        len(rcm.config),
+		Polling:     rcm.polling.Load() > 0,
+	}
+	if rcm.lastErr != nil {
+		stats.LastError = rcm.lastErr.Error()
+	}
+
+	return stats
+}
@@ -0,0 +1,78 @@
+package rcm
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+func TestStats(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	serviceName := "test_service"
+	rcm := NewRedisConfigManagerWithClient(serviceName, client).(*RedisConfigManager)
+
+	if err := rcm.LoadConfig(context.Background()); err == nil {
+		t.Fatal("expected LoadConfig to fail for a missing key")
+	}
+	stats := rcm.Stats()
+	if stats.Loads != 1 || stats.LoadErrors != 1 || stats.LastError == "" {
+		t.Errorf("expected one failed load, got %+v", stats)
+	}
+
+	if err := mr.Set(serviceName, `{"a": 1, "b": 2}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+	rcm.StartLoading(time.Hour)
+	defer rcm.StopLoading()
+
+	stats = rcm.Stats()
+	if stats.Loads != 2 || stats.LoadErrors != 1 || stats.LastError != "" {
+		t.Errorf("expected a successful second load, got %+v", stats)
+	}
+	if stats.Keys != 2 || !stats.Polling || stats.LastUpdated.IsZero() {
+		t.Errorf("expected 2 keys, polling and a load time, got %+v", stats)
+	}
+	if _, err := json.Marshal(stats); err != nil {
+		t.Errorf("expected Stats to serialize, got %v", err)
+	}
+
+	rcm.StopLoading()
+	if rcm.Stats().Polling {
+		t.Error("expected polling to be reported as stopped")
+	}
+}
+
+func TestStats_PollingEndsWithParentContext(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	serviceName := "test_service"
+	if err := mr.Set(serviceName, `{"a": 1}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	rcm := NewRedisConfigManagerWithContext(ctx, serviceName, &redis.Options{Addr: mr.Addr()}).(*RedisConfigManager)
+	defer rcm.StopLoading()
+
+	rcm.StartLoading(time.Hour)
+	if !rcm.Stats().Polling {
+		t.Fatal("expected polling to be reported while the loop runs")
+	}
+
+	cancel()
+	deadline := time.Now().Add(time.Second)
+	for rcm.Stats().Polling {
+		if time.Now().After(deadline) {
+			t.Fatal("expected polling to end with the parent context")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
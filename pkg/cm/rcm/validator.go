@@ -0,0 +1,52 @@
+package rcm
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/zemld/config-manager/pkg/cm"
+)
+
+// SetValidator registers a function that checks every newly loaded config
+// before it replaces the current one. When it returns an error, LoadConfig
+// keeps the previous config and returns the error, so a malformed push does
+// not take effect. The validator must not modify the map or call back into
+// the manager's loading methods.
+func (rcm *RedisConfigManager) SetValidator(validator func(config map[string]string) error) {
+	rcm.mu.Lock()
+	defer rcm.mu.Unlock()
+
+	rcm.validator = validator
+}
+
+// ValidateDocument checks a config document without a connection, for
+// example in CI or before publishing it to Redis. The document is decoded and
+// built by the same code as LoadConfig for a manager configured with opts, so
+// the format, key transform, case folding and decryptor apply, and every key
+// in required must be present, using dotted paths for nested keys. Fallback
+// keys, defaults and sources are not read. All missing keys are reported
+// together.
+func ValidateDocument(raw []byte, required []string, opts ...Option) error {
+	rcm := &RedisConfigManager{}
+	for _, opt := range opts {
+		opt(rcm)
+	}
+
+	document, err := rcm.decode(raw)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal config: %w", err)
+	}
+	built, err := rcm.buildConfig(document)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, key := range required {
+		if _, ok := built.values[rcm.foldKey(key)]; !ok {
+			errs = append(errs, fmt.Errorf("%w: %s", cm.ErrKeyNotFound, key))
+		}
+	}
+
+	return errors.Join(errs...)
+}
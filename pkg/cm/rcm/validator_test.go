@@ -0,0 +1,107 @@
+package rcm
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/zemld/config-manager/pkg/cm"
+)
+
+func TestSetValidator(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	serviceName := "test_service"
+	if err := mr.Set(serviceName, `{"port": 8080}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+
+	rcm := &RedisConfigManager{
+		serviceName: serviceName,
+		config:      make(map[string]string),
+		r:           client,
+		ctx:         context.Background(),
+	}
+
+	errInvalidPort := errors.New("port out of range")
+	rcm.SetValidator(func(config map[string]string) error {
+		if config["port"] == "0" {
+			return errInvalidPort
+		}
+		return nil
+	})
+
+	if err := rcm.LoadConfig(context.Background()); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	if err := mr.Set(serviceName, `{"port": 0}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+
+	err := rcm.LoadConfig(context.Background())
+	if !errors.Is(err, errInvalidPort) {
+		t.Fatalf("expected validator error, got %v", err)
+	}
+	if !errors.Is(rcm.LastError(), errInvalidPort) {
+		t.Errorf("expected LastError to surface the validator error, got %v", rcm.LastError())
+	}
+
+	port, err := rcm.GetInt("port")
+	if err != nil || port != 8080 {
+		t.Errorf("expected previous port 8080 to be kept, got %d (%v)", port, err)
+	}
+
+	if err := rcm.Set(context.Background(), "port", 0); !errors.Is(err, errInvalidPort) {
+		t.Errorf("expected Set to be rejected by the validator, got %v", err)
+	}
+	if stored, _ := mr.Get(serviceName); stored != `{"port": 0}` {
+		t.Errorf("expected rejected document not to be written, got %s", stored)
+	}
+}
+
+func TestValidateDocument(t *testing.T) {
+	raw := []byte(`{"port": 8080, "db": {"host": "localhost"}}`)
+
+	if err := ValidateDocument(raw, []string{"port", "db.host"}); err != nil {
+		t.Errorf("expected a valid document, got %v", err)
+	}
+
+	err := ValidateDocument(raw, []string{"port", "db.user", "timeout"})
+	if !errors.Is(err, cm.ErrKeyNotFound) {
+		t.Fatalf("expected ErrKeyNotFound, got %v", err)
+	}
+	for _, key := range []string{"db.user", "timeout"} {
+		if !strings.Contains(err.Error(), key) {
+			t.Errorf("expected the error to list %s, got %v", key, err)
+		}
+	}
+
+	if err := ValidateDocument([]byte(`{"port": 8080,}`), nil); err == nil {
+		t.Error("expected an error for malformed JSON")
+	}
+}
+
+func TestValidateDocument_Options(t *testing.T) {
+	raw := []byte(`{"Max-Conns": 10, "password": "enc:secret"}`)
+
+	transform := WithKeyTransform(func(key string) string {
+		return strings.ReplaceAll(key, "-", "")
+	})
+	if err := ValidateDocument(raw, []string{"maxconns"}, transform, WithCaseInsensitiveKeys(true)); err != nil {
+		t.Errorf("expected the key transform and case folding to apply, got %v", err)
+	}
+	if err := ValidateDocument(raw, []string{"MaxConns"}); !errors.Is(err, cm.ErrKeyNotFound) {
+		t.Errorf("expected ErrKeyNotFound without options, got %v", err)
+	}
+
+	failing := WithDecryptor(func(string) (string, error) {
+		return "", errors.New("bad key")
+	})
+	if err := ValidateDocument(raw, nil, failing); err == nil || !strings.Contains(err.Error(), "password") {
+		t.Errorf("expected the decryption failure to be reported, got %v", err)
+	}
+}
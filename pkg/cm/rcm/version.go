@@ -0,0 +1,65 @@
+package rcm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// WithVersionKey makes LoadConfig read a version or etag string from key,
+// for example "<serviceName>:version", before fetching the config. When it
+// matches the version of the config already applied, the document is
+// neither fetched nor parsed and the load counts as successful. A missing
+// version key falls back to a full load.
+//
+// Producers must write the new document before bumping the version, and
+// must bump it whenever any source of the config changes, including
+// fallback keys. Set bumps it with INCR in the same transaction as the
+// write, or right after it with a cluster client, so a version key shared
+// with Set must hold an integer.
+func WithVersionKey(key string) Option {
+	return func(rcm *RedisConfigManager) {
+		rcm.versionKey = key
+	}
+}
+
+// currentVersion returns the version stored under the version key, or an
+// empty string when versioning is off or the key is missing. unchanged
+// reports whether that version was already applied from configKey.
+func (rcm *RedisConfigManager) currentVersion(ctx context.Context, client redis.UniversalClient, configKey string) (version string, unchanged bool, err error) {
+	if rcm.versionKey == "" {
+		return "", false, nil
+	}
+
+	version, err = client.Get(ctx, rcm.versionKey).Result()
+	if errors.Is(err, redis.Nil) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, fmt.Errorf("failed to get config version: %w", err)
+	}
+
+	rcm.mu.RLock()
+	defer rcm.mu.RUnlock()
+
+	return version, rcm.appliedVersion == version && rcm.versionedKey == configKey, nil
+}
+
+// recordVersion remembers the version applied from configKey. An empty
+// version clears it, so the next load fetches the document again. The caller
+// must hold the write lock.
+func (rcm *RedisConfigManager) recordVersion(configKey, version string) {
+	rcm.appliedVersion = version
+	rcm.versionedKey = configKey
+}
+
+// markUnchanged records a load that found the config up to date.
+func (rcm *RedisConfigManager) markUnchanged() {
+	rcm.mu.Lock()
+	defer rcm.mu.Unlock()
+
+	rcm.updatedAt = rcm.now()
+	rcm.lastChanged = nil
+}
@@ -0,0 +1,174 @@
+package rcm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+)
+
+func TestWithVersionKey(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	ctx := context.Background()
+	rcm := NewRedisConfigManagerWithClient("myservice", client, WithVersionKey("myservice:version")).(*RedisConfigManager)
+
+	if err := mr.Set("myservice", `{"value": "v1"}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+	if err := rcm.LoadConfig(ctx); err != nil {
+		t.Fatalf("LoadConfig failed without a version key: %v", err)
+	}
+
+	if err := mr.Set("myservice", `{"value": "v2"}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+	if err := mr.Set("myservice:version", "2"); err != nil {
+		t.Fatalf("failed to set version in miniredis: %v", err)
+	}
+	if err := rcm.LoadConfig(ctx); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if value, _ := rcm.GetString("value"); value != "v2" {
+		t.Errorf("expected v2, got %q", value)
+	}
+
+	if err := mr.Set("myservice", `{"value": "v3"}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+	before := rcm.LastUpdated()
+	if err := rcm.LoadConfig(ctx); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if value, _ := rcm.GetString("value"); value != "v2" {
+		t.Errorf("expected the document to be skipped for an unchanged version, got %q", value)
+	}
+	if !rcm.LastUpdated().After(before) {
+		t.Error("expected a skipped load to refresh LastUpdated")
+	}
+
+	if err := mr.Set("myservice:version", "3"); err != nil {
+		t.Fatalf("failed to set version in miniredis: %v", err)
+	}
+	if err := rcm.LoadConfig(ctx); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if value, _ := rcm.GetString("value"); value != "v3" {
+		t.Errorf("expected v3 after a version bump, got %q", value)
+	}
+
+	if err := mr.Set("myservice", `{"value": "v4"}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+	mr.Del("myservice:version")
+	if err := rcm.LoadConfig(ctx); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if value, _ := rcm.GetString("value"); value != "v4" {
+		t.Errorf("expected a full load without a version key, got %q", value)
+	}
+}
+
+func TestWithVersionKey_SetBumpsVersion(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	ctx := context.Background()
+	if err := mr.Set("myservice", `{"value": "v1"}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+	if err := mr.Set("myservice:version", "1"); err != nil {
+		t.Fatalf("failed to set version in miniredis: %v", err)
+	}
+
+	writer := NewRedisConfigManagerWithClient("myservice", client, WithVersionKey("myservice:version")).(*RedisConfigManager)
+	reader := NewRedisConfigManagerWithClient("myservice", client, WithVersionKey("myservice:version")).(*RedisConfigManager)
+	for _, rcm := range []*RedisConfigManager{writer, reader} {
+		if err := rcm.LoadConfig(ctx); err != nil {
+			t.Fatalf("LoadConfig failed: %v", err)
+		}
+	}
+
+	if err := writer.Set(ctx, "value", "v2"); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if version, _ := mr.Get("myservice:version"); version != "2" {
+		t.Errorf("expected Set to bump the version to 2, got %q", version)
+	}
+
+	if err := reader.LoadConfig(ctx); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if value, _ := reader.GetString("value"); value != "v2" {
+		t.Errorf("expected another manager to see the value from Set, got %q", value)
+	}
+
+	// The writer recorded the bumped version with its own swap, so a
+	// document changed without a bump is not fetched.
+	if err := mr.Set("myservice", `{"value": "v3"}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+	if err := writer.LoadConfig(ctx); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if value, _ := writer.GetString("value"); value != "v2" {
+		t.Errorf("expected the version applied by Set to be recorded, got %q", value)
+	}
+}
+
+func TestWithVersionKey_HashSetBumpsVersion(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	ctx := context.Background()
+	mr.HSet("myservice", "value", "v1")
+
+	rcm := NewRedisConfigManagerWithClient("myservice", client, WithVersionKey("myservice:version")).(*RedisConfigManager)
+	rcm.hash = true
+	if err := rcm.LoadConfig(ctx); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	if err := rcm.Set(ctx, "value", "v2"); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if version, _ := mr.Get("myservice:version"); version != "1" {
+		t.Errorf("expected Set to bump the version to 1, got %q", version)
+	}
+	if value, _ := rcm.GetString("value"); value != "v2" {
+		t.Errorf("expected v2, got %q", value)
+	}
+}
+
+func TestWithVersionKey_SetWithClusterClient(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	ctx := context.Background()
+	if err := mr.Set("myservice", `{"value": "v1"}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+
+	cluster := redis.NewClusterClient(&redis.ClusterOptions{Addrs: []string{mr.Addr()}})
+	defer cluster.Close()
+
+	rcm := NewRedisConfigManagerWithClient("myservice", cluster, WithVersionKey("myservice:version")).(*RedisConfigManager)
+	if err := rcm.LoadConfig(ctx); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	if err := rcm.Set(ctx, "value", "v2"); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if version, _ := mr.Get("myservice:version"); version != "1" {
+		t.Errorf("expected Set to bump the version after the write, got %q", version)
+	}
+	if value, _ := rcm.GetString("value"); value != "v2" {
+		t.Errorf("expected v2, got %q", value)
+	}
+}
@@ -0,0 +1,109 @@
+package rcm
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/zemld/config-manager/pkg/cm/internal/conv"
+)
+
+// view is an immutable snapshot of everything the getters read. Writers
+// change the manager's fields under the write lock and then publish a new
+// view, so the getters load the current one from an atomic pointer instead
+// of taking the lock on every read. Maps referenced by a published view are
+// never modified; writers replace them instead.
+type view struct {
+	config          map[string]string
+	natives         map[string]any
+	overrides       map[string]string
+	overrideNatives map[string]any
+	defaults        map[string]string
+	defaultNatives  map[string]any
+	parsed          *sync.Map
+	foldCase        bool
+}
+
+// publish resets the parsed value cache and makes the current fields visible
+// to the getters. The caller must hold the write lock.
+func (rcm *RedisConfigManager) publish() {
+	rcm.resetParsedCache()
+	rcm.current.Store(rcm.newView())
+}
+
+// view returns the published view. A manager that has not published one
+// yet, such as one built as a struct literal, gets a view of its fields
+// taken under the read lock.
+func (rcm *RedisConfigManager) view() *view {
+	if v := rcm.current.Load(); v != nil {
+		return v
+	}
+
+	rcm.mu.RLock()
+	defer rcm.mu.RUnlock()
+
+	return rcm.newView()
+}
+
+func (rcm *RedisConfigManager) newView() *view {
+	return &view{
+		config:          rcm.config,
+		natives:         rcm.natives,
+		overrides:       rcm.overrides,
+		overrideNatives: rcm.overrideNatives,
+		defaults:        rcm.defaults,
+		defaultNatives:  rcm.defaultNatives,
+		parsed:          rcm.parsed,
+		foldCase:        rcm.foldCase,
+	}
+}
+
+// value resolves key from the overrides, then the live config and finally
+// the registered defaults.
+func (v *view) value(key string) (string, bool) {
+	key = v.lookupKey(key)
+	if value, ok := v.overrides[key]; ok {
+		return value, true
+	}
+	if value, ok := v.config[key]; ok {
+		return value, true
+	}
+
+	value, ok := v.defaults[key]
+	return value, ok
+}
+
+// native returns the decoded value key resolves to, checking the same
+// sources in the same order as value. Values set from Go code keep their Go
+// type; values from a hash backend are strings.
+func (v *view) native(key string) (any, bool) {
+	key = v.lookupKey(key)
+	if _, ok := v.overrides[key]; ok {
+		native, ok := v.overrideNatives[key]
+		return native, ok
+	}
+	if _, ok := v.config[key]; ok {
+		native, ok := v.natives[key]
+		return native, ok
+	}
+	if _, ok := v.defaults[key]; ok {
+		native, ok := v.defaultNatives[key]
+		return native, ok
+	}
+
+	return nil, false
+}
+
+// composite reports whether key resolves to an object or array.
+func (v *view) composite(key string) bool {
+	native, _ := v.native(key)
+	return conv.IsComposite(native)
+}
+
+// lookupKey returns key in the form the view stores it under.
+func (v *view) lookupKey(key string) string {
+	if !v.foldCase {
+		return key
+	}
+
+	return strings.ToLower(key)
+}
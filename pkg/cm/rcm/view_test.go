@@ -0,0 +1,71 @@
+package rcm
+
+import (
+	"strconv"
+	"testing"
+)
+
+func newBenchmarkManager() *RedisConfigManager {
+	config := make(map[string]string, 1000)
+	for i := 0; i < 1000; i++ {
+		config["key_"+strconv.Itoa(i)] = strconv.Itoa(i)
+	}
+
+	rcm := &RedisConfigManager{config: config}
+	rcm.mu.Lock()
+	rcm.publish()
+	rcm.mu.Unlock()
+
+	return rcm
+}
+
+// BenchmarkGetString compares the lock-free read path with the same lookup
+// done under the read lock, as the getters used to, at ten times
+// GOMAXPROCS goroutines.
+func BenchmarkGetString(b *testing.B) {
+	b.Run("mutex", func(b *testing.B) {
+		rcm := newBenchmarkManager()
+		b.SetParallelism(10)
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				rcm.mu.RLock()
+				view := rcm.current.Load()
+				if _, ok := view.value("key_42"); !ok || view.composite("key_42") {
+					b.Error("expected key_42 to be a string")
+				}
+				rcm.mu.RUnlock()
+			}
+		})
+	})
+
+	b.Run("atomic", func(b *testing.B) {
+		rcm := newBenchmarkManager()
+		b.SetParallelism(10)
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				if _, err := rcm.GetString("key_42"); err != nil {
+					b.Error(err)
+				}
+			}
+		})
+	})
+}
+
+func TestOverride_DoesNotModifyPublishedView(t *testing.T) {
+	rcm := &RedisConfigManager{config: map[string]string{"mode": "fast"}}
+	rcm.Override("mode", "slow")
+	before := rcm.view()
+
+	rcm.Override("mode", "safe")
+	rcm.SetDefaults(map[string]any{"port": 8080})
+
+	if value, _ := before.value("mode"); value != "slow" {
+		t.Errorf("expected the earlier view to keep its override, got %q", value)
+	}
+	if _, ok := before.value("port"); ok {
+		t.Error("expected the earlier view not to see later defaults")
+	}
+	if value, _ := rcm.GetString("mode"); value != "safe" {
+		t.Errorf("expected the latest override, got %q", value)
+	}
+}
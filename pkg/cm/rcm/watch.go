@@ -0,0 +1,119 @@
+package rcm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// ErrNotificationsDisabled is returned by StartWatching when the server has
+// keyspace notifications turned off. Callers should fall back to StartLoading.
+var ErrNotificationsDisabled = errors.New("keyspace notifications are disabled")
+
+// ErrWatchCluster is returned by StartWatching for a cluster client, because
+// keyspace notifications are only published by the node that owns the key
+// and a subscription listens on a single node. Callers should fall back to
+// StartLoading.
+var ErrWatchCluster = errors.New("keyspace notifications are not supported with a cluster client")
+
+// StartWatching loads the config and then reloads it every time the config
+// key changes, using Redis keyspace notifications instead of polling.
+// The server must have notify-keyspace-events including "K" and "$" (or "h"
+// for hash-backed managers), or "A".
+// If the server reports otherwise, ErrNotificationsDisabled is returned and
+// nothing is started. Cluster clients are rejected with ErrWatchCluster.
+// Like StartLoading, it returns nil without starting anything while a
+// loading or watching loop is already running, including one that a
+// timed-out StopLoadingWithTimeout is still waiting for. Watching stops when
+// ctx is done or StopLoading is called.
+func (rcm *RedisConfigManager) StartWatching(ctx context.Context) error {
+	if _, ok := rcm.client().(*redis.ClusterClient); ok {
+		return ErrWatchCluster
+	}
+
+	if !rcm.started.CompareAndSwap(false, true) {
+		return nil
+	}
+	l := rcm.restart()
+
+	if err := rcm.checkNotifications(ctx); err != nil {
+		rcm.started.Store(false)
+		return err
+	}
+
+	channel := fmt.Sprintf("__keyspace@%d__:%s", rcm.database(), rcm.configKey())
+	pubsub := l.client.Subscribe(ctx, channel)
+	if _, err := pubsub.Receive(ctx); err != nil {
+		pubsub.Close()
+		rcm.started.Store(false)
+		return fmt.Errorf("failed to subscribe to %s: %w", channel, err)
+	}
+
+	rcm.reload(l)
+
+	rcm.wg.Add(1)
+	go func() {
+		defer rcm.wg.Done()
+		defer pubsub.Close()
+
+		rcm.watchUpdates(ctx, l, pubsub.Channel())
+	}()
+
+	return nil
+}
+
+func (rcm *RedisConfigManager) watchUpdates(ctx context.Context, l loop, messages <-chan *redis.Message) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-l.ctx.Done():
+			return
+		case _, ok := <-messages:
+			if !ok {
+				rcm.log().Warnf("keyspace subscription for %s closed, watching stopped", rcm.configKey())
+				return
+			}
+			rcm.reloadRecovering(l)
+		}
+	}
+}
+
+// checkNotifications reports ErrNotificationsDisabled only when the server
+// confirms notifications are off. Servers that refuse CONFIG GET (managed
+// offerings often do) are given the benefit of the doubt.
+func (rcm *RedisConfigManager) checkNotifications(ctx context.Context) error {
+	settings, err := rcm.client().ConfigGet(ctx, "notify-keyspace-events").Result()
+	if err != nil {
+		return nil
+	}
+
+	flags, ok := settings["notify-keyspace-events"]
+	if !ok {
+		return nil
+	}
+
+	eventClass := "$"
+	if rcm.hash {
+		eventClass = "h"
+	}
+
+	if !strings.Contains(flags, "K") || !strings.ContainsAny(flags, eventClass+"A") {
+		return ErrNotificationsDisabled
+	}
+
+	return nil
+}
+
+// database returns the selected database number. Only single-node and
+// failover clients can select one; every other client uses database 0.
+func (rcm *RedisConfigManager) database() int {
+	if client, ok := rcm.client().(*redis.Client); ok {
+		return client.Options().DB
+	}
+
+	return 0
+}
@@ -0,0 +1,168 @@
+package rcm
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+func TestStartWatching(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	serviceName := "test_service"
+	if err := mr.Set(serviceName, `{"string_key": "old"}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+
+	rcm := &RedisConfigManager{
+		serviceName: serviceName,
+		config:      make(map[string]string),
+		r:           client,
+	}
+	rcm.ctx, rcm.cancel = context.WithCancel(context.Background())
+
+	if err := rcm.StartWatching(context.Background()); err != nil {
+		t.Fatalf("StartWatching failed: %v", err)
+	}
+	defer rcm.StopLoading()
+
+	if value, _ := rcm.GetString("string_key"); value != "old" {
+		t.Fatalf("expected initial value 'old', got '%s'", value)
+	}
+
+	if err := mr.Set(serviceName, `{"string_key": "new"}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+	mr.Publish("__keyspace@0__:"+serviceName, "set")
+
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if value, _ := rcm.GetString("string_key"); value == "new" {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	t.Error("config was not reloaded after keyspace notification")
+}
+
+func TestStartWatching_ContextCancel(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	serviceName := "test_service"
+	if err := mr.Set(serviceName, `{"string_key": "old"}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+
+	rcm := &RedisConfigManager{
+		serviceName: serviceName,
+		config:      make(map[string]string),
+		r:           client,
+	}
+	rcm.ctx, rcm.cancel = context.WithCancel(context.Background())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	if err := rcm.StartWatching(ctx); err != nil {
+		t.Fatalf("StartWatching failed: %v", err)
+	}
+
+	cancel()
+
+	done := make(chan struct{})
+	go func() {
+		rcm.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Error("watcher did not stop after context cancellation")
+	}
+}
+
+func TestStartWatching_ClusterClient(t *testing.T) {
+	client := redis.NewClusterClient(&redis.ClusterOptions{Addrs: []string{"127.0.0.1:0"}})
+	defer client.Close()
+
+	rcm := NewRedisConfigManagerWithClient("test_service", client).(*RedisConfigManager)
+	if err := rcm.StartWatching(context.Background()); !errors.Is(err, ErrWatchCluster) {
+		t.Fatalf("expected ErrWatchCluster, got %v", err)
+	}
+	if rcm.Stats().Polling {
+		t.Error("expected nothing to be started for a cluster client")
+	}
+}
+
+func TestStartWatching_AfterTimedOutStop(t *testing.T) {
+	mr, client := setupTestRedis(t)
+	defer mr.Close()
+	defer client.Close()
+
+	serviceName := "test_service"
+	if err := mr.Set(serviceName, `{"string_key": "value"}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+
+	stuck := make(chan struct{})
+	release := make(chan struct{})
+	var once bool
+	rcm := NewRedisConfigManagerWithClient(serviceName, client).(*RedisConfigManager)
+	if err := rcm.StartWatching(context.Background()); err != nil {
+		t.Fatalf("StartWatching failed: %v", err)
+	}
+	rcm.SetValidator(func(map[string]string) error {
+		if !once {
+			once = true
+			close(stuck)
+			<-release
+		}
+		return nil
+	})
+	mr.Publish("__keyspace@0__:"+serviceName, "set")
+	<-stuck
+
+	if err := rcm.StopLoadingWithTimeout(10 * time.Millisecond); !errors.Is(err, ErrStopTimeout) {
+		t.Fatalf("expected ErrStopTimeout for a hung reload, got %v", err)
+	}
+	if err := rcm.StartWatching(context.Background()); err != nil {
+		t.Fatalf("expected StartWatching to be a no-op during a pending stop, got %v", err)
+	}
+	if err := rcm.StopLoadingWithTimeout(10 * time.Millisecond); !errors.Is(err, ErrStopTimeout) {
+		t.Errorf("expected a second stop to join the pending one, got %v", err)
+	}
+
+	close(release)
+	if err := rcm.StopLoadingWithTimeout(time.Second); err != nil {
+		t.Fatalf("expected the pending stop to finish, got %v", err)
+	}
+	if rcm.started.Load() {
+		t.Fatal("expected the stop to clear the started flag")
+	}
+
+	updates := rcm.Watch()
+	if err := rcm.StartWatching(context.Background()); err != nil {
+		t.Fatalf("StartWatching failed after the stop finished: %v", err)
+	}
+	defer rcm.StopLoading()
+
+	if err := mr.Set(serviceName, `{"string_key": "restarted"}`); err != nil {
+		t.Fatalf("failed to set config in miniredis: %v", err)
+	}
+	mr.Publish("__keyspace@0__:"+serviceName, "set")
+	select {
+	case _, ok := <-updates:
+		if !ok {
+			t.Fatal("expected a watcher registered after the stop to stay open")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected a notification from the restarted watcher")
+	}
+}
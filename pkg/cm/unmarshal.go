@@ -0,0 +1,62 @@
+package cm
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FieldError describes a struct field that Unmarshal could not set.
+type FieldError struct {
+	Field string
+	Key   string
+	Err   error
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("field %s (key %s): %v", e.Field, e.Key, e.Err)
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// UnmarshalError is returned by Unmarshal when one or more fields could not
+// be set. It lists every failure, so all of them can be fixed in one pass,
+// and works with errors.Is and errors.As through Unwrap.
+type UnmarshalError struct {
+	Fields []*FieldError
+}
+
+func (e *UnmarshalError) Error() string {
+	messages := make([]string, len(e.Fields))
+	for i, field := range e.Fields {
+		messages[i] = field.Error()
+	}
+
+	return fmt.Sprintf("cannot unmarshal %d field(s): %s", len(e.Fields), strings.Join(messages, "; "))
+}
+
+func (e *UnmarshalError) Unwrap() []error {
+	errs := make([]error, len(e.Fields))
+	for i, field := range e.Fields {
+		errs[i] = field
+	}
+
+	return errs
+}
+
+// Details formats the error with one field per line, for logs and startup
+// output:
+//
+//	cannot unmarshal 2 field(s):
+//	  Port (key port): ...
+//	  Debug (key debug): ...
+func (e *UnmarshalError) Details() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "cannot unmarshal %d field(s):", len(e.Fields))
+	for _, field := range e.Fields {
+		fmt.Fprintf(&b, "\n  %s (key %s): %v", field.Field, field.Key, field.Err)
+	}
+
+	return b.String()
+}
@@ -0,0 +1,52 @@
+package cm_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/zemld/config-manager/pkg/cm"
+	"github.com/zemld/config-manager/pkg/cm/mcm"
+)
+
+func TestUnmarshalError(t *testing.T) {
+	manager := mcm.NewMockConfigManager(map[string]any{
+		"port":  "8080",
+		"debug": "maybe",
+		"name":  "svc",
+	})
+
+	var target struct {
+		Port  int    `cm:"port"`
+		Debug bool   `cm:"debug"`
+		Name  string `cm:"name"`
+	}
+	err := manager.Unmarshal(&target)
+
+	var unmarshalErr *cm.UnmarshalError
+	if !errors.As(err, &unmarshalErr) {
+		t.Fatalf("expected an UnmarshalError, got %v", err)
+	}
+	if len(unmarshalErr.Fields) != 2 {
+		t.Fatalf("expected 2 failed fields, got %d: %v", len(unmarshalErr.Fields), err)
+	}
+	if field := unmarshalErr.Fields[0]; field.Field != "Port" || field.Key != "port" {
+		t.Errorf("expected the Port field first, got %+v", field)
+	}
+	if !errors.Is(err, cm.ErrTypeMismatch) {
+		t.Errorf("expected the field errors to be unwrapped, got %v", err)
+	}
+
+	var fieldErr *cm.FieldError
+	if !errors.As(err, &fieldErr) || fieldErr.Field != "Port" {
+		t.Errorf("expected errors.As to find the first field error, got %v", fieldErr)
+	}
+
+	details := unmarshalErr.Details()
+	if lines := strings.Split(details, "\n"); len(lines) != 3 {
+		t.Errorf("expected a header and one line per field, got %q", details)
+	}
+	if target.Name != "svc" {
+		t.Errorf("expected valid fields to be set, got Name %q", target.Name)
+	}
+}